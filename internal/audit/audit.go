@@ -0,0 +1,221 @@
+// ABOUTME: Append-only JSONL audit log of every mutating action (archive, delete, visibility, transfer, topics, edit, ...).
+// ABOUTME: Stored next to the repo cache so "who changed this repo and when" can be answered later.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/cache"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// Result values for Entry.Result
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// State is the repo state captured before an action
+type State struct {
+	Archived   bool      `json:"archived"`
+	Visibility string    `json:"visibility"`
+	Fork       bool      `json:"fork"`
+	Stars      int       `json:"stars"`
+	Forks      int       `json:"forks"`
+	PushedAt   time.Time `json:"pushedAt"`
+}
+
+// StateOf captures the audit-relevant state of a repo
+func StateOf(r repo.Repo) *State {
+	return &State{
+		Archived:   r.IsArchived,
+		Visibility: strings.ToLower(r.VisibilityString()),
+		Fork:       r.IsFork,
+		Stars:      r.StargazerCount,
+		Forks:      r.ForkCount,
+		PushedAt:   r.PushedAt,
+	}
+}
+
+// Entry is one line of the audit log. Details holds the action's parameters,
+// e.g. a transfer's new owner, so the change can be traced and reverted.
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Actor      string            `json:"actor"`
+	Source     string            `json:"source"`
+	Action     string            `json:"action"`
+	Repo       string            `json:"repo"`
+	PriorState *State            `json:"priorState,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// DetailString renders the details as sorted key=value pairs
+func (e Entry) DetailString() string {
+	keys := make([]string, 0, len(e.Details))
+	for key := range e.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s=%q", key, e.Details[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Logger appends entries for one actor and source ("tui" or "cli")
+type Logger struct {
+	Actor  string
+	Source string
+}
+
+// mu serializes appends from concurrent commands
+var mu sync.Mutex
+
+// Path returns the audit log location
+func Path() (string, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// Record appends an entry for action on fullName. prior and details may be
+// nil when unknown or when the action has no parameters.
+func (l Logger) Record(action, fullName string, prior *State, details map[string]string, actionErr error) error {
+	entry := Entry{
+		Time:       time.Now().UTC(),
+		Actor:      l.Actor,
+		Source:     l.Source,
+		Action:     action,
+		Repo:       fullName,
+		PriorState: prior,
+		Details:    details,
+		Result:     ResultSuccess,
+	}
+	if actionErr != nil {
+		entry.Result = ResultError
+		entry.Error = actionErr.Error()
+	}
+	return Append(entry)
+}
+
+// Append writes a single entry to the end of the log
+func Append(entry Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Read returns all entries, oldest first. A missing log yields no entries.
+// Lines that fail to parse are skipped so one bad write can't hide history.
+func Read() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Query selects entries; zero fields match everything
+type Query struct {
+	Action string
+	Repo   string // substring, case-insensitive
+	Actor  string
+	Result string
+	Since  time.Time
+	Text   string // free text matched against repo, action, actor and error
+}
+
+// Matches reports whether e satisfies the query
+func (q Query) Matches(e Entry) bool {
+	if q.Action != "" && !strings.EqualFold(e.Action, q.Action) {
+		return false
+	}
+	if q.Repo != "" && !strings.Contains(strings.ToLower(e.Repo), strings.ToLower(q.Repo)) {
+		return false
+	}
+	if q.Actor != "" && !strings.EqualFold(e.Actor, q.Actor) {
+		return false
+	}
+	if q.Result != "" && !strings.EqualFold(e.Result, q.Result) {
+		return false
+	}
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		haystack := strings.ToLower(strings.Join([]string{e.Repo, e.Action, e.Actor, e.Result, e.Error, e.DetailString()}, " "))
+		if !strings.Contains(haystack, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the matching entries, newest first
+func Filter(entries []Entry, q Query) []Entry {
+	var result []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if q.Matches(entries[i]) {
+			result = append(result, entries[i])
+		}
+	}
+	return result
+}
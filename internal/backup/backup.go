@@ -0,0 +1,175 @@
+// ABOUTME: Creates offline backups of repositories before they are deleted.
+// ABOUTME: Each backup holds a git bundle of a mirror clone plus JSON exports of metadata.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/gh"
+)
+
+// exports lists the REST collections saved as JSON, keyed by file name. An
+// export with a feature is skipped when the repository has it turned off.
+var exports = []struct {
+	file    string
+	path    string
+	feature string
+}{
+	{"issues.json", "repos/%s/issues?state=all&per_page=100", "has_issues"},
+	{"pulls.json", "repos/%s/pulls?state=all&per_page=100", ""},
+	{"releases.json", "repos/%s/releases?per_page=100", ""},
+	{"labels.json", "repos/%s/labels?per_page=100", ""},
+}
+
+// Result describes a finished backup
+type Result struct {
+	Repo     string
+	Dir      string
+	Bundle   string
+	Warnings []string
+}
+
+// DefaultDir returns $XDG_DATA_HOME/gh-repo-review/backups,
+// falling back to ~/.local/share/gh-repo-review/backups
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "gh-repo-review", "backups"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "gh-repo-review", "backups"), nil
+}
+
+// Run backs up fullName into a new timestamped directory below baseDir.
+// Any error means the backup is incomplete and the repo must not be deleted.
+func Run(client *gh.Client, fullName, baseDir string) (Result, error) {
+	result := Result{Repo: fullName}
+
+	info, err := client.GetRepoStats(fullName)
+	if err != nil {
+		return result, err
+	}
+
+	dir, err := filepath.Abs(filepath.Join(baseDir, filepath.FromSlash(fullName)+"-"+time.Now().Format("20060102-150405")))
+	if err != nil {
+		return result, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return result, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	result.Dir = dir
+
+	if err := writeJSON(filepath.Join(dir, "repository.json"), info); err != nil {
+		return result, err
+	}
+
+	cloneURL, _ := info["clone_url"].(string)
+	if cloneURL == "" {
+		return result, fmt.Errorf("no clone URL for %s", fullName)
+	}
+
+	bundle, err := mirrorBundle(cloneURL, filepath.Join(dir, "repo.git"), filepath.Join(dir, "repo.bundle"))
+	if err != nil {
+		return result, err
+	}
+	if bundle == "" {
+		result.Warnings = append(result.Warnings, "repository is empty; no bundle created")
+	}
+	result.Bundle = bundle
+
+	if hasWiki, _ := info["has_wiki"].(bool); hasWiki {
+		wikiURL := strings.TrimSuffix(cloneURL, ".git") + ".wiki.git"
+		if _, err := mirrorBundle(wikiURL, filepath.Join(dir, "wiki.git"), filepath.Join(dir, "wiki.bundle")); err != nil {
+			// GitHub reports wikis without pages as missing, so this is not fatal
+			result.Warnings = append(result.Warnings, "wiki not backed up: "+err.Error())
+		}
+	}
+
+	for _, e := range exports {
+		if enabled, ok := info[e.feature].(bool); ok && !enabled {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s skipped: %s is off", e.file, e.feature))
+			continue
+		}
+		items, err := client.GetPaginated(fmt.Sprintf(e.path, fullName))
+		var apiErr *gh.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusGone {
+			// GitHub answers 410 Gone for features that are disabled, so there is nothing to save
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s skipped: %s", e.file, apiErr.Message))
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if err := writeJSON(filepath.Join(dir, e.file), items); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// mirrorBundle mirror-clones url into mirrorDir and bundles all refs into
+// bundlePath. The mirror is removed afterwards. An empty repository yields
+// an empty bundle path and no error.
+func mirrorBundle(url, mirrorDir, bundlePath string) (string, error) {
+	defer os.RemoveAll(mirrorDir)
+
+	// Let gh supply credentials so private repositories clone without extra setup
+	if err := git("", "-c", "credential.helper=", "-c", "credential.helper=!gh auth git-credential",
+		"clone", "--mirror", "--quiet", url, mirrorDir); err != nil {
+		return "", fmt.Errorf("mirror clone failed: %w", err)
+	}
+
+	refs, err := exec.Command("git", "-C", mirrorDir, "for-each-ref").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list refs: %w", err)
+	}
+	if len(bytes.TrimSpace(refs)) == 0 {
+		return "", nil
+	}
+
+	if err := git(mirrorDir, "bundle", "create", bundlePath, "--all"); err != nil {
+		return "", fmt.Errorf("git bundle failed: %w", err)
+	}
+	return bundlePath, nil
+}
+
+// git runs a git command, returning stderr as the error message
+func git(dir string, args ...string) error {
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
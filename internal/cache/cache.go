@@ -1,5 +1,5 @@
 // ABOUTME: Caches repository data to avoid slow API calls on startup.
-// ABOUTME: Uses time-based invalidation with 5-minute TTL.
+// ABOUTME: Uses time-based invalidation with a 5-minute TTL by default.
 
 package cache
 
@@ -7,24 +7,33 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/user/gh-repo-review/internal/repo"
 )
 
-const (
-	cacheTTL = 5 * time.Minute
-)
+// cacheTTL is how long cached data counts as fresh
+var cacheTTL = 5 * time.Minute
+
+// SetTTL changes how long cached data counts as fresh
+func SetTTL(ttl time.Duration) {
+	cacheTTL = ttl
+}
+
+// cacheVersion is bumped when repo.Repo gains fields, so older caches are refetched
+const cacheVersion = 5
 
 // CachedData holds the cached repository data with metadata.
 type CachedData struct {
-	Username string      `json:"username"`
+	Version  int         `json:"version"`
+	Key      string      `json:"key"`
 	CachedAt time.Time   `json:"cached_at"`
 	Repos    []repo.Repo `json:"repos"`
 }
 
-// cacheDir returns the cache directory path.
-func cacheDir() (string, error) {
+// Dir returns the cache directory path.
+func Dir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -32,19 +41,29 @@ func cacheDir() (string, error) {
 	return filepath.Join(home, ".cache", "gh-repo-review"), nil
 }
 
-// cacheFilePath returns the cache file path for a given username.
-func cacheFilePath(username string) (string, error) {
-	dir, err := cacheDir()
+// Key builds the cache key for an owner and an optional affiliation set.
+// Affiliations other than the default OWNER get their own file. Logins may
+// contain "-" but never "_", so "_" keeps owner and affiliations apart.
+func Key(owner string, affiliations []string) string {
+	if len(affiliations) == 0 || (len(affiliations) == 1 && affiliations[0] == "OWNER") {
+		return owner
+	}
+	return owner + "_" + strings.ToLower(strings.Join(affiliations, "+"))
+}
+
+// cacheFilePath returns the cache file path for a given cache key.
+func cacheFilePath(key string) (string, error) {
+	dir, err := Dir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, username+"-repos.json"), nil
+	return filepath.Join(dir, key+"-repos.json"), nil
 }
 
-// Load reads cached repos for a user. Returns repos, whether cache is fresh, and any error.
+// Load reads cached repos for a key. Returns repos, whether cache is fresh, and any error.
 // If cache doesn't exist or is corrupted, returns nil repos with no error.
-func Load(username string) ([]repo.Repo, bool, error) {
-	path, err := cacheFilePath(username)
+func Load(key string) ([]repo.Repo, bool, error) {
+	path, err := cacheFilePath(key)
 	if err != nil {
 		return nil, false, err
 	}
@@ -62,14 +81,17 @@ func Load(username string) ([]repo.Repo, bool, error) {
 		// Corrupted cache, treat as miss
 		return nil, false, nil
 	}
+	if cached.Version != cacheVersion {
+		return nil, false, nil
+	}
 
 	fresh := time.Since(cached.CachedAt) < cacheTTL
 	return cached.Repos, fresh, nil
 }
 
-// Save writes repos to cache for a user.
-func Save(username string, repos []repo.Repo) error {
-	dir, err := cacheDir()
+// Save writes repos to cache for a key.
+func Save(key string, repos []repo.Repo) error {
+	dir, err := Dir()
 	if err != nil {
 		return err
 	}
@@ -78,13 +100,14 @@ func Save(username string, repos []repo.Repo) error {
 		return err
 	}
 
-	path, err := cacheFilePath(username)
+	path, err := cacheFilePath(key)
 	if err != nil {
 		return err
 	}
 
 	cached := CachedData{
-		Username: username,
+		Version:  cacheVersion,
+		Key:      key,
 		CachedAt: time.Now(),
 		Repos:    repos,
 	}
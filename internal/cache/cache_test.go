@@ -0,0 +1,51 @@
+// ABOUTME: Tests for cache keys and the save/load round trip.
+// ABOUTME: Keys must stay distinct for every owner and affiliation set.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		owner        string
+		affiliations []string
+		want         string
+	}{
+		{"alice", nil, "alice"},
+		{"alice", []string{"OWNER"}, "alice"},
+		{"alice", []string{"OWNER", "COLLABORATOR"}, "alice_owner+collaborator"},
+		{"alice", []string{"ORGANIZATION_MEMBER"}, "alice_organization_member"},
+		{"alice-collaborator", nil, "alice-collaborator"},
+		{"alice", []string{"COLLABORATOR"}, "alice_collaborator"},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.owner, tt.affiliations); got != tt.want {
+			t.Errorf("Key(%q, %v) = %q, want %q", tt.owner, tt.affiliations, got, tt.want)
+		}
+	}
+
+	// Logins contain "-", so the affiliations must not be joined with it
+	if Key("alice-collaborator", nil) == Key("alice", []string{"COLLABORATOR"}) {
+		t.Error("an owner's key collides with another owner's affiliation key")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repos := []repo.Repo{{FullName: "alice/tool", Contributors: -1, Dependents: -1}}
+	if err := Save(Key("alice", []string{"COLLABORATOR"}), repos); err != nil {
+		t.Fatal(err)
+	}
+
+	got, fresh, err := Load(Key("alice", []string{"COLLABORATOR"}))
+	if err != nil || !fresh || len(got) != 1 || got[0].FullName != "alice/tool" || got[0].Dependents != -1 {
+		t.Errorf("Load = %v, %v, %v", got, fresh, err)
+	}
+	if got, _, _ := Load(Key("alice", nil)); got != nil {
+		t.Errorf("another affiliation set loaded %v", got)
+	}
+}
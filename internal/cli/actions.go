@@ -0,0 +1,407 @@
+// ABOUTME: Archive, unarchive and delete subcommands.
+// ABOUTME: Targets come from explicit owner/repo arguments or from the filter flags.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/audit"
+	"github.com/user/gh-repo-review/internal/backup"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/plan"
+	"github.com/user/gh-repo-review/internal/repo"
+	"github.com/user/gh-repo-review/internal/worker"
+)
+
+// mutation describes a state-changing subcommand
+type mutation struct {
+	name string
+	verb string // past tense, used in output
+	// applies reports whether a filtered repo is a valid target
+	applies func(r repo.Repo) bool
+	// needsArchived forces archived repos into the filtered set
+	needsArchived bool
+	// canBackup enables the --backup flags
+	canBackup bool
+	// guarded refuses repos above the configured delete limits without --force
+	guarded bool
+	// protects skips repos protected in the config
+	protects bool
+	// canNotice enables the --notice flags
+	canNotice bool
+	run       func(c *gh.Client, fullName string) error
+	// details are the parameters recorded in the audit log; may be nil
+	details map[string]string
+}
+
+var (
+	archiveMutation = mutation{
+		name:      "archive",
+		verb:      "Archived",
+		applies:   func(r repo.Repo) bool { return !r.IsArchived },
+		protects:  true,
+		canNotice: true,
+		run:       (*gh.Client).ArchiveRepo,
+	}
+	unarchiveMutation = mutation{
+		name:          "unarchive",
+		verb:          "Unarchived",
+		applies:       func(r repo.Repo) bool { return r.IsArchived },
+		needsArchived: true,
+		run:           (*gh.Client).UnarchiveRepo,
+	}
+	deleteMutation = mutation{
+		name:      "delete",
+		verb:      "Deleted",
+		applies:   func(r repo.Repo) bool { return true },
+		canBackup: true,
+		guarded:   true,
+		protects:  true,
+		run:       (*gh.Client).DeleteRepo,
+	}
+)
+
+func runArchive(args []string, stdout, stderr io.Writer) error {
+	return runMutation(archiveMutation, args, stdout, stderr)
+}
+
+func runUnarchive(args []string, stdout, stderr io.Writer) error {
+	return runMutation(unarchiveMutation, args, stdout, stderr)
+}
+
+func runDelete(args []string, stdout, stderr io.Writer) error {
+	return runMutation(deleteMutation, args, stdout, stderr)
+}
+
+// runMutation resolves the targets for a mutation and applies it to each
+func runMutation(m mutation, args []string, stdout, stderr io.Writer) error {
+	var ff filterFlags
+	var sf sourceFlags
+	var yes, all, dryRun bool
+	var planFormat string
+	fs := newFlagSet(m.name, m.name+" [flags] [owner/repo ...]", stderr)
+	ff.register(fs)
+	sf.register(fs)
+	fs.BoolVar(&yes, "yes", false, "Confirm the operation (required unless --dry-run)")
+	fs.BoolVar(&all, "all", false, "Allow targeting every repository when no filter flags are given")
+	fs.BoolVar(&dryRun, "dry-run", false, "Print the plan without changing anything")
+	fs.StringVar(&planFormat, "output", "text", "Dry-run plan format: text or json")
+	var concurrency int
+	fs.IntVar(&concurrency, "concurrency", worker.DefaultWorkers, "Number of repositories to change in parallel")
+	var doBackup, force bool
+	var backupDir string
+	if m.guarded {
+		fs.BoolVar(&force, "force", false, "Allow repositories above the delete limits in the config")
+	}
+	if m.canBackup {
+		fs.BoolVar(&doBackup, "backup", false, "Back up each repository first and skip it if the backup fails")
+		fs.StringVar(&backupDir, "backup-dir", "", "Backup directory (default ~/.local/share/gh-repo-review/backups)")
+	}
+	var notice bool
+	var movedTo string
+	if m.canNotice {
+		fs.BoolVar(&notice, "notice", userConfig.ArchiveNotice.Enabled, "Commit the archive notice to README.md first and skip the repository if that fails")
+		fs.StringVar(&movedTo, "moved-to", "", "New location mentioned in the archive notice")
+	}
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+	if notice {
+		m = withNotice(m, movedTo)
+	}
+	if planFormat != "text" && planFormat != "json" {
+		return fmt.Errorf("invalid --output %q (want text or json)", planFormat)
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	client := gh.NewClient()
+	targets := fs.Args()
+	p := plan.Named(m.name, targets)
+	// Prior state for the audit log, known only for filter-selected repos
+	prior := make(map[string]*audit.State)
+	var matched []repo.Repo
+
+	if len(targets) == 0 {
+		if !anyFilterSet(fs) && !all {
+			return fmt.Errorf("refusing to %s every repository: pass owner/repo names, filter flags or --all", m.name)
+		}
+
+		opts, err := ff.options(fs)
+		if err != nil {
+			return err
+		}
+		if m.needsArchived {
+			opts.ShowArchived = true
+		}
+
+		repos, err := fetchRepos(client, sf)
+		if err != nil {
+			return err
+		}
+		fillContributors(client, repos, opts)
+
+		filtered := repo.Filter(repos, opts)
+		repo.Sort(filtered, opts.SortBy, opts.SortDesc)
+		for _, r := range filtered {
+			if !m.applies(r) || (m.protects && skipProtected(r, stderr)) {
+				continue
+			}
+			matched = append(matched, r)
+			targets = append(targets, r.FullName)
+			prior[r.FullName] = audit.StateOf(r)
+		}
+		if len(targets) == 0 {
+			fmt.Fprintf(stdout, "No repositories matched; nothing to %s.\n", m.name)
+			return nil
+		}
+		p = plan.New(m.name, matched, opts)
+	} else if m.protects {
+		repos, err := lookupRepos(client, targets)
+		if err != nil {
+			return err
+		}
+		targets = nil
+		for _, r := range repos {
+			if !skipProtected(r, stderr) {
+				matched = append(matched, r)
+				targets = append(targets, r.FullName)
+			}
+		}
+		if len(targets) == 0 {
+			fmt.Fprintf(stdout, "Every repository is protected; nothing to %s.\n", m.name)
+			return nil
+		}
+		p = plan.Named(m.name, targets)
+	}
+
+	if dryRun {
+		if planFormat == "json" {
+			return p.WriteJSON(stdout)
+		}
+		return p.WriteText(stdout)
+	}
+
+	if m.guarded && !force {
+		if matched == nil {
+			repos, err := lookupRepos(client, targets)
+			if err != nil {
+				return err
+			}
+			matched = repos
+		}
+		if err := checkDeleteGuard(client, matched, stderr); err != nil {
+			return err
+		}
+	}
+
+	if !yes {
+		for _, name := range targets {
+			fmt.Fprintf(stderr, "  %s\n", name)
+		}
+		return fmt.Errorf("refusing to %s %d %s without --yes", m.name, len(targets), pluralize(len(targets), "repository", "repositories"))
+	}
+
+	eo := execOptions{concurrency: concurrency}
+	if doBackup {
+		if backupDir == "" {
+			dir, err := backup.DefaultDir()
+			if err != nil {
+				return err
+			}
+			backupDir = dir
+		}
+		eo.backupDir = backupDir
+	}
+
+	failed, err := executeMutation(client, m, targets, prior, eo, stdout, stderr)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %s operations failed", failed, len(targets), m.name)
+	}
+	return nil
+}
+
+// execOptions controls how executeMutation applies a mutation
+type execOptions struct {
+	// backupDir enables backups before the mutation when set
+	backupDir   string
+	concurrency int
+}
+
+// executeMutation applies m to targets on a worker pool, recording each result in
+// the audit log, and returns the number of failures
+func executeMutation(client *gh.Client, m mutation, targets []string, prior map[string]*audit.State, eo execOptions, stdout, stderr io.Writer) (int, error) {
+	actor, err := client.GetCurrentUser()
+	if err != nil {
+		return 0, err
+	}
+	logger := audit.Logger{Actor: actor, Source: "cli"}
+	record := func(name string, actionErr error) {
+		if err := logger.Record(m.name, name, prior[name], m.details, actionErr); err != nil {
+			fmt.Fprintf(stderr, "Warning: failed to write audit log: %v\n", err)
+		}
+	}
+
+	failed := 0
+	if eo.backupDir != "" {
+		// Backups run one at a time; only repos backed up successfully go on
+		var backedUp []string
+		for _, name := range targets {
+			result, err := backup.Run(client, name, eo.backupDir)
+			if err != nil {
+				failed++
+				record(name, fmt.Errorf("backup failed, not deleted: %w", err))
+				fmt.Fprintf(stderr, "Error: backup of %s failed, not deleting: %v\n", name, err)
+				continue
+			}
+			for _, w := range result.Warnings {
+				fmt.Fprintf(stderr, "Warning: %s: %s\n", name, w)
+			}
+			fmt.Fprintf(stdout, "Backed up: %s -> %s\n", name, result.Dir)
+			backedUp = append(backedUp, name)
+		}
+		targets = backedUp
+	}
+
+	tasks := make([]worker.Task, len(targets))
+	for i, name := range targets {
+		name := name
+		tasks[i] = func() error { return m.run(client, name) }
+	}
+	pool := worker.New(worker.Options{Workers: eo.concurrency})
+	for ev := range pool.Run(tasks) {
+		name := targets[ev.Index]
+		switch ev.State {
+		case worker.Retrying:
+			fmt.Fprintf(stderr, "Retrying %s in %s: %v\n", name, ev.Wait.Round(time.Second), ev.Err)
+		case worker.Finished:
+			record(name, ev.Err)
+			if ev.Err != nil {
+				failed++
+				fmt.Fprintf(stderr, "Error: %v\n", ev.Err)
+				continue
+			}
+			fmt.Fprintf(stdout, "%s: %s\n", m.verb, name)
+		}
+	}
+	return failed, nil
+}
+
+// checkDeleteGuard refuses repos above the configured delete limits, listing them on stderr
+func checkDeleteGuard(client *gh.Client, repos []repo.Repo, stderr io.Writer) error {
+	repos = fillDependents(client, repos, stderr)
+	blocked := 0
+	for _, r := range repos {
+		if reasons := userConfig.Delete.Blockers(r); len(reasons) > 0 {
+			blocked++
+			fmt.Fprintf(stderr, "  %s: %s\n", r.FullName, strings.Join(reasons, ", "))
+		}
+	}
+	if blocked > 0 {
+		return fmt.Errorf("refusing to delete %d %s above the delete limits without --force", blocked, pluralize(blocked, "repository", "repositories"))
+	}
+	return nil
+}
+
+// fillDependents returns repos with their dependents counted when the
+// dependents limit is on. Repos whose count can't be fetched are noted on
+// stderr; the limit doesn't apply to them.
+func fillDependents(client *gh.Client, repos []repo.Repo, stderr io.Writer) []repo.Repo {
+	if userConfig.Delete.MaxDependents < 0 || len(repos) == 0 {
+		return repos
+	}
+	names := make([]string, len(repos))
+	for i, r := range repos {
+		names[i] = r.FullName
+	}
+	counts := client.GetDependentsCounts(names)
+	filled := make([]repo.Repo, len(repos))
+	unknown := 0
+	for i, r := range repos {
+		if n, ok := counts[r.FullName]; ok {
+			r.Dependents = n
+		} else if r.Dependents < 0 {
+			unknown++
+		}
+		filled[i] = r
+	}
+	if unknown > 0 {
+		fmt.Fprintf(stderr, "Could not count dependents of %d %s; the dependents limit doesn't apply to %s\n",
+			unknown, pluralize(unknown, "repository", "repositories"), pluralize(unknown, "it", "them"))
+	}
+	return filled
+}
+
+// withNotice makes m commit the configured archive notice before running
+func withNotice(m mutation, movedTo string) mutation {
+	notice := userConfig.ArchiveNotice
+	run := m.run
+	m.details = map[string]string{"notice": "README.md"}
+	if movedTo != "" {
+		m.details["moved_to"] = movedTo
+	}
+	m.run = func(c *gh.Client, fullName string) error {
+		text, err := notice.Render(fullName, movedTo)
+		if err != nil {
+			return fmt.Errorf("archive notice: %w", err)
+		}
+		if err := c.PrependReadme(fullName, text, notice.CommitMessage); err != nil {
+			return fmt.Errorf("not archived: %w", err)
+		}
+		return run(c, fullName)
+	}
+	return m
+}
+
+// skipProtected reports whether r is protected by the config, noting the skip on stderr
+func skipProtected(r repo.Repo, stderr io.Writer) bool {
+	reason := userConfig.Protect.Reason(r)
+	if reason == "" {
+		return false
+	}
+	fmt.Fprintf(stderr, "Skipping protected %s (%s)\n", r.FullName, reason)
+	return true
+}
+
+// lookupRepos fetches what the delete limits and protection rules need to know
+// about repositories named on the command line
+func lookupRepos(client *gh.Client, names []string) ([]repo.Repo, error) {
+	repos := make([]repo.Repo, len(names))
+	for i, name := range names {
+		stats, err := client.GetRepoStats(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		stars, _ := stats["stargazers_count"].(float64)
+		forks, _ := stats["forks_count"].(float64)
+		var topics []string
+		if list, ok := stats["topics"].([]interface{}); ok {
+			for _, t := range list {
+				if s, ok := t.(string); ok {
+					topics = append(topics, s)
+				}
+			}
+		}
+		marker, err := client.HasProtectMarker(name)
+		if err != nil {
+			return nil, err
+		}
+		repos[i] = repo.Repo{FullName: name, StargazerCount: int(stars), ForkCount: int(forks), Topics: topics, HasProtectMarker: marker, Contributors: -1, Dependents: -1}
+	}
+	return repos, nil
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
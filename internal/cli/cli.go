@@ -0,0 +1,397 @@
+// ABOUTME: Non-interactive subcommands (list, archive, unarchive, delete) for scripting.
+// ABOUTME: Reuses the gh client and repo filtering so results match what the TUI shows.
+
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/user/gh-repo-review/internal/config"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/output"
+	"github.com/user/gh-repo-review/internal/query"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// command is a single subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"list", "List repositories matching the filter flags", runList},
+	{"recommend", "Rank unarchived repositories by archive score", runRecommend},
+	{"archive", "Archive repositories by name or by filter", runArchive},
+	{"unarchive", "Unarchive repositories by name or by filter", runUnarchive},
+	{"delete", "Permanently delete repositories by name or by filter", runDelete},
+	{"log", "Show the audit log of archives, deletes, visibility changes, transfers, topic and description edits", runLog},
+	{"policy", "Evaluate the retention policy; 'policy apply --execute' carries it out", runPolicy},
+}
+
+// userConfig supplies filter defaults and presets, loaded by Run
+var userConfig = config.New()
+
+// Run executes the subcommand named by args[0]
+func Run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "help" {
+		Usage(stdout)
+		return nil
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("invalid config: %w", err)
+			}
+			userConfig = cfg
+			return c.run(args[1:], stdout, stderr)
+		}
+	}
+	Usage(stderr)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+// Usage prints the list of subcommands
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gh repo-review [command] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a command the interactive TUI is started.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'gh repo-review <command> -h' for command flags.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Query syntax for --query and the TUI search box:")
+	fmt.Fprintln(w, query.Help)
+}
+
+// filterFlags mirrors repo.FilterOptions on the command line
+type filterFlags struct {
+	visibility    string
+	archived      bool
+	forks         bool
+	language      string
+	license       string
+	minStars      int
+	maxStars      int
+	inactiveDays  int
+	inactiveBy    string
+	search        string
+	query         string
+	topics        StringList
+	excludeTopics StringList
+	sort          string
+	asc           bool
+	preset        string
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	defaults := userConfig.DefaultOptions()
+	visibility := "all"
+	switch {
+	case defaults.OnlyInternal:
+		visibility = "internal"
+	case !defaults.ShowPrivate:
+		visibility = "public"
+	case !defaults.ShowPublic:
+		visibility = "private"
+	}
+	fs.StringVar(&f.visibility, "visibility", visibility, "Visibility to include: all, public, private or internal (private includes internal)")
+	fs.BoolVar(&f.archived, "archived", defaults.ShowArchived, "Include archived repositories")
+	fs.BoolVar(&f.forks, "forks", defaults.ShowForks, "Include forked repositories")
+	fs.StringVar(&f.language, "language", defaults.Language, "Only repositories with this primary language")
+	fs.StringVar(&f.license, "license", defaults.License, "Only repositories with this SPDX license id, or none for unlicensed")
+	fs.IntVar(&f.minStars, "min-stars", defaults.MinStars, "Minimum stars (-1 for no limit)")
+	fs.IntVar(&f.maxStars, "max-stars", defaults.MaxStars, "Maximum stars (-1 for no limit)")
+	fs.IntVar(&f.inactiveDays, "inactive-days", defaults.InactiveForDays, "Only repositories without activity in this many days")
+	fs.StringVar(&f.inactiveBy, "inactive-by", defaults.InactiveBy.Name(), "Activity that counts for --inactive-days: any, push, commit, issues, prs or release")
+	fs.StringVar(&f.search, "search", "", "Substring to match in name or description")
+	fs.StringVar(&f.query, "query", "", "Query such as 'lang:go stars:<5 -is:fork' (overrides --search; see 'gh repo-review help')")
+	fs.Var(&f.topics, "topic", "Only repositories with one of these topics (repeatable)")
+	fs.Var(&f.excludeTopics, "exclude-topic", "Skip repositories with any of these topics (repeatable)")
+	fs.StringVar(&f.sort, "sort", defaults.SortBy.Name(), "Sort by: name, updated, created, stars, forks, size, activity, committed, released, prs, contributors or score")
+	fs.BoolVar(&f.asc, "asc", !defaults.SortDesc, "Sort in ascending order")
+	fs.StringVar(&f.preset, "preset", "", "Start from a saved filter preset; other filter flags override it")
+}
+
+// options converts the parsed flags into repo.FilterOptions, starting from
+// the configured defaults or --preset. Only flags passed explicitly override them.
+func (f *filterFlags) options(fs *flag.FlagSet) (repo.FilterOptions, error) {
+	opts := userConfig.DefaultOptions()
+	if f.preset != "" {
+		var err error
+		if opts, err = userConfig.PresetOptions(f.preset); err != nil {
+			return opts, err
+		}
+	}
+
+	passed := make(map[string]bool)
+	fs.Visit(func(fl *flag.Flag) { passed[fl.Name] = true })
+	use := func(name string) bool { return passed[name] }
+
+	if use("visibility") {
+		opts.OnlyInternal = false
+		switch strings.ToLower(f.visibility) {
+		case "all", "":
+			opts.ShowPrivate, opts.ShowPublic = true, true
+		case "public":
+			opts.ShowPrivate, opts.ShowPublic = false, true
+		case "private":
+			opts.ShowPrivate, opts.ShowPublic = true, false
+		case "internal":
+			opts.ShowPrivate, opts.ShowPublic = true, false
+			opts.OnlyInternal = true
+		default:
+			return opts, fmt.Errorf("invalid --visibility %q (want all, public, private or internal)", f.visibility)
+		}
+	}
+
+	if use("sort") {
+		sortBy, err := repo.ParseSortField(f.sort)
+		if err != nil {
+			return opts, err
+		}
+		opts.SortBy = sortBy
+	}
+
+	if use("archived") {
+		opts.ShowArchived = f.archived
+	}
+	if use("forks") {
+		opts.ShowForks = f.forks
+	}
+	if use("language") {
+		opts.Language = f.language
+	}
+	if use("license") {
+		opts.License = f.license
+	}
+	if use("min-stars") {
+		opts.MinStars = f.minStars
+	}
+	if use("max-stars") {
+		opts.MaxStars = f.maxStars
+	}
+	if use("inactive-days") {
+		opts.InactiveForDays = f.inactiveDays
+	}
+	if use("inactive-by") {
+		signal, err := repo.ParseActivitySignal(f.inactiveBy)
+		if err != nil {
+			return opts, err
+		}
+		opts.InactiveBy = signal
+	}
+	if use("search") {
+		opts.SearchQuery = f.search
+	}
+	if f.query != "" {
+		q, err := query.Parse(f.query)
+		if err != nil {
+			return opts, err
+		}
+		opts.SearchQuery = f.query
+		opts.Query = q
+	}
+	if use("topic") {
+		opts.Topics = f.topics
+	}
+	if use("exclude-topic") {
+		opts.ExcludeTopics = f.excludeTopics
+	}
+	if use("asc") {
+		opts.SortDesc = !f.asc
+	}
+	return opts, nil
+}
+
+// filterFlagNames lists the flags that narrow the selection
+var filterFlagNames = map[string]bool{
+	"visibility":    true,
+	"archived":      true,
+	"forks":         true,
+	"language":      true,
+	"license":       true,
+	"min-stars":     true,
+	"max-stars":     true,
+	"inactive-days": true,
+	"inactive-by":   true,
+	"search":        true,
+	"query":         true,
+	"topic":         true,
+	"exclude-topic": true,
+	"preset":        true,
+}
+
+// anyFilterSet reports whether a narrowing filter flag was passed explicitly
+func anyFilterSet(fs *flag.FlagSet) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if filterFlagNames[f.Name] {
+			set = true
+		}
+	})
+	return set
+}
+
+// StringList is a flag that may be repeated or given as a comma-separated list
+type StringList []string
+
+func (l *StringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set appends one or more comma-separated values
+func (l *StringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// sourceFlags selects whose repositories are listed
+type sourceFlags struct {
+	owners      StringList
+	affiliation string
+}
+
+func (s *sourceFlags) register(fs *flag.FlagSet) {
+	fs.Var(&s.owners, "owner", "User or organization whose repositories to list (repeatable, default: you)")
+	fs.StringVar(&s.affiliation, "affiliation", "owner", "Your affiliations to include: owner, collaborator, organization_member")
+}
+
+// outputFlags selects how listings are rendered
+type outputFlags struct {
+	format   string
+	template string
+}
+
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "output", string(output.FormatTable), "Output format: table, json, ndjson, csv or tsv")
+	fs.StringVar(&o.template, "template", "", "Format output with a Go template (data is the list of repositories)")
+}
+
+// write renders repos using the selected format or template
+func (o *outputFlags) write(w io.Writer, repos []repo.Repo) error {
+	format, err := output.ParseFormat(o.format)
+	if err != nil {
+		return err
+	}
+	return output.Write(w, repos, format, o.template)
+}
+
+// newFlagSet creates a flag set that reports errors instead of exiting
+func newFlagSet(name, usage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gh repo-review %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, treating -h as a successful no-op
+func parseFlags(fs *flag.FlagSet, args []string) (bool, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// fillContributors fetches contributor counts when opts sort or query on
+// them; they aren't part of the repository listing
+func fillContributors(client *gh.Client, repos []repo.Repo, opts repo.FilterOptions) {
+	if !repo.NeedsContributors(opts) {
+		return
+	}
+	names := make([]string, len(repos))
+	for i, r := range repos {
+		names[i] = r.FullName
+	}
+	counts := client.GetContributorCounts(names)
+	for i := range repos {
+		if n, ok := counts[repos[i].FullName]; ok {
+			repos[i].Contributors = n
+		}
+	}
+}
+
+// fetchRepos checks authentication and lists repositories for every owner
+func fetchRepos(client *gh.Client, src sourceFlags) ([]repo.Repo, error) {
+	affiliations, err := gh.ParseAffiliations(src.affiliation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.CheckAuth(); err != nil {
+		return nil, fmt.Errorf("not authenticated with gh CLI: %w", err)
+	}
+
+	if len(src.owners) == 0 {
+		return client.ListRepos(gh.ListOptions{Affiliations: affiliations})
+	}
+
+	var all []repo.Repo
+	seen := make(map[string]bool)
+	for _, owner := range src.owners {
+		opts := gh.ListOptions{Owner: owner}
+		if owner == "@me" {
+			opts = gh.ListOptions{Affiliations: affiliations}
+		}
+		repos, err := client.ListRepos(opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", owner, err)
+		}
+		for _, r := range repos {
+			if !seen[r.FullName] {
+				seen[r.FullName] = true
+				all = append(all, r)
+			}
+		}
+	}
+	return all, nil
+}
+
+func runList(args []string, stdout, stderr io.Writer) error {
+	var ff filterFlags
+	var sf sourceFlags
+	var of outputFlags
+	fs := newFlagSet("list", "list [flags]", stderr)
+	ff.register(fs)
+	sf.register(fs)
+	of.register(fs)
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	opts, err := ff.options(fs)
+	if err != nil {
+		return err
+	}
+
+	client := gh.NewClient()
+	repos, err := fetchRepos(client, sf)
+	if err != nil {
+		return err
+	}
+	fillContributors(client, repos, opts)
+
+	filtered := repo.Filter(repos, opts)
+	repo.Sort(filtered, opts.SortBy, opts.SortDesc)
+	return of.write(stdout, filtered)
+}
@@ -0,0 +1,99 @@
+// ABOUTME: The log subcommand for browsing and filtering the audit log.
+// ABOUTME: Prints newest entries first as a table, JSON or NDJSON.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/audit"
+)
+
+func runLog(args []string, stdout, stderr io.Writer) error {
+	var q audit.Query
+	var since, format string
+	var limit int
+	fs := newFlagSet("log", "log [flags]", stderr)
+	fs.StringVar(&q.Action, "action", "", "Only entries for this action (archive, unarchive, delete, make-public, make-private, make-internal, transfer, topics, edit)")
+	fs.StringVar(&q.Repo, "repo", "", "Only entries whose repository contains this text")
+	fs.StringVar(&q.Actor, "actor", "", "Only entries by this user")
+	fs.StringVar(&q.Result, "result", "", "Only entries with this result (success or error)")
+	fs.StringVar(&since, "since", "", "Only entries newer than a duration (30d, 12h) or date (2006-01-02)")
+	fs.IntVar(&limit, "limit", 0, "Show at most this many entries (0 for all)")
+	fs.StringVar(&format, "output", "table", "Output format: table, json or ndjson")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	if since != "" {
+		t, err := parseSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+		q.Since = t
+	}
+
+	entries, err := audit.Read()
+	if err != nil {
+		return err
+	}
+	entries = audit.Filter(entries, q)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	switch format {
+	case "table":
+		return writeLogTable(stdout, entries)
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case "ndjson":
+		enc := json.NewEncoder(stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q (want table, json or ndjson)", format)
+	}
+}
+
+// parseSince accepts "30d", Go durations such as "12h", or a date
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (want e.g. 30d, 12h or 2006-01-02)", s)
+}
+
+func writeLogTable(w io.Writer, entries []audit.Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tACTOR\tSOURCE\tACTION\tREPO\tRESULT\tDETAILS")
+	for _, e := range entries {
+		result := e.Result
+		if e.Error != "" {
+			result += ": " + e.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Actor, e.Source, e.Action, e.Repo, result, e.DetailString())
+	}
+	return tw.Flush()
+}
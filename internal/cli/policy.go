@@ -0,0 +1,163 @@
+// ABOUTME: The policy subcommand: evaluates the retention policy and prints or executes its plan.
+// ABOUTME: Plans are the same as dry-run plans, with the matching rule as the first reason.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/user/gh-repo-review/internal/audit"
+	"github.com/user/gh-repo-review/internal/backup"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/policy"
+	"github.com/user/gh-repo-review/internal/repo"
+	"github.com/user/gh-repo-review/internal/worker"
+)
+
+// policyMutations maps rule actions to the mutations that carry them out
+var policyMutations = map[string]mutation{
+	policy.ActionArchive: archiveMutation,
+	policy.ActionDelete:  deleteMutation,
+}
+
+func runPolicy(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "apply" {
+		fmt.Fprintln(stderr, "Usage: gh repo-review policy apply [flags]")
+		if len(args) == 0 {
+			return nil
+		}
+		return fmt.Errorf("unknown policy command %q", args[0])
+	}
+	return runPolicyApply(args[1:], stdout, stderr)
+}
+
+func runPolicyApply(args []string, stdout, stderr io.Writer) error {
+	var sf sourceFlags
+	var policyPath, planFormat, backupDir string
+	var execute, doBackup, force bool
+	var concurrency int
+	fs := newFlagSet("policy apply", "policy apply [flags]", stderr)
+	sf.register(fs)
+	fs.StringVar(&policyPath, "policy", "", "Policy file (default ~/.config/gh-repo-review/policy.yml)")
+	fs.BoolVar(&execute, "execute", false, "Carry out the plan instead of only printing it")
+	fs.StringVar(&planFormat, "output", "text", "Plan format: text or json")
+	fs.BoolVar(&doBackup, "backup", false, "Back up repositories before deleting them and skip any whose backup fails")
+	fs.StringVar(&backupDir, "backup-dir", "", "Backup directory (default ~/.local/share/gh-repo-review/backups)")
+	fs.IntVar(&concurrency, "concurrency", worker.DefaultWorkers, "Number of repositories to change in parallel")
+	fs.BoolVar(&force, "force", false, "Allow deleting repositories above the delete limits in the config")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+	if planFormat != "text" && planFormat != "json" {
+		return fmt.Errorf("invalid --output %q (want text or json)", planFormat)
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	if policyPath == "" {
+		path, err := policy.DefaultPath()
+		if err != nil {
+			return err
+		}
+		policyPath = path
+	}
+	pol, err := policy.Load(policyPath)
+	if err != nil {
+		return err
+	}
+
+	client := gh.NewClient()
+	repos, err := fetchRepos(client, sf)
+	if err != nil {
+		return err
+	}
+	for _, rule := range pol.Rules {
+		if opts, err := rule.Match.Options(); err == nil && repo.NeedsContributors(opts) {
+			fillContributors(client, repos, opts)
+			break
+		}
+	}
+
+	var violations []policy.Violation
+	for _, v := range pol.Evaluate(repos) {
+		if !skipProtected(v.Repo, stderr) {
+			violations = append(violations, v)
+		}
+	}
+	plans := pol.Plans(violations)
+	if len(plans) == 0 {
+		fmt.Fprintln(stdout, "No repositories violate the policy.")
+		return nil
+	}
+
+	if !execute {
+		for _, p := range plans {
+			if planFormat == "json" {
+				err = p.WriteJSON(stdout)
+			} else {
+				err = p.WriteText(stdout)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !force {
+		var deletes []repo.Repo
+		for _, v := range violations {
+			if v.Action == policy.ActionDelete {
+				deletes = append(deletes, v.Repo)
+			}
+		}
+		if err := checkDeleteGuard(client, deletes, stderr); err != nil {
+			return err
+		}
+	}
+
+	eo := execOptions{concurrency: concurrency}
+	if doBackup {
+		if backupDir == "" {
+			if backupDir, err = backup.DefaultDir(); err != nil {
+				return err
+			}
+		}
+		eo.backupDir = backupDir
+	}
+
+	failed, total := 0, 0
+	for _, p := range plans {
+		m := policyMutations[p.Action]
+		if m.canNotice && userConfig.ArchiveNotice.Enabled {
+			// Same as the archive command's --notice default
+			m = withNotice(m, "")
+		}
+		var targets []string
+		prior := make(map[string]*audit.State)
+		for _, v := range violations {
+			if v.Action == p.Action {
+				targets = append(targets, v.Repo.FullName)
+				prior[v.Repo.FullName] = audit.StateOf(v.Repo)
+			}
+		}
+
+		mo := eo
+		if !m.canBackup {
+			mo.backupDir = ""
+		}
+		n, err := executeMutation(client, m, targets, prior, mo, stdout, stderr)
+		if err != nil {
+			return err
+		}
+		failed += n
+		total += len(targets)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d policy operations failed", failed, total)
+	}
+	return nil
+}
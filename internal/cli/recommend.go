@@ -0,0 +1,97 @@
+// ABOUTME: The recommend subcommand ranks unarchived repositories by archive score.
+// ABOUTME: The table explains which factors contributed most to each score.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/output"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func runRecommend(args []string, stdout, stderr io.Writer) error {
+	var ff filterFlags
+	var sf sourceFlags
+	var of outputFlags
+	var limit int
+	fs := newFlagSet("recommend", "recommend [flags]", stderr)
+	ff.register(fs)
+	sf.register(fs)
+	of.register(fs)
+	fs.IntVar(&limit, "limit", 20, "Show at most this many candidates (0 for all)")
+	var dependents bool
+	fs.BoolVar(&dependents, "dependents", false, "Count dependents so they lower the scores (reads one web page per repository)")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	opts, err := ff.options(fs)
+	if err != nil {
+		return err
+	}
+
+	client := gh.NewClient()
+	repos, err := fetchRepos(client, sf)
+	if err != nil {
+		return err
+	}
+	fillContributors(client, repos, opts)
+
+	// Protected repos are never archive candidates
+	var unprotected []repo.Repo
+	for _, r := range repo.Filter(repos, opts) {
+		if userConfig.Protect.Reason(r) == "" {
+			unprotected = append(unprotected, r)
+		}
+	}
+	if dependents {
+		names := make([]string, len(unprotected))
+		for i, r := range unprotected {
+			names[i] = r.FullName
+		}
+		counts := client.GetDependentsCounts(names)
+		for i := range unprotected {
+			if n, ok := counts[unprotected[i].FullName]; ok {
+				unprotected[i].Dependents = n
+			}
+		}
+	}
+	candidates := repo.Recommend(unprotected, limit)
+	if of.template != "" || of.format != string(output.FormatTable) {
+		return of.write(stdout, candidates)
+	}
+	return writeRecommendTable(stdout, candidates)
+}
+
+func writeRecommendTable(w io.Writer, repos []repo.Repo) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORE\tNAME\tREASONS")
+	unknown := 0
+	for _, r := range repos {
+		score := r.ArchiveScore()
+		var reasons []string
+		for _, f := range score.TopFactors(3) {
+			reasons = append(reasons, fmt.Sprintf("%s (+%.0f)", f.Detail, f.Points))
+		}
+		for _, f := range score.Penalties() {
+			reasons = append(reasons, fmt.Sprintf("%s (%.0f)", f.Detail, f.Points))
+		}
+		if len(score.Unknown()) > 0 {
+			unknown++
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", score.Total, r.FullName, strings.Join(reasons, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if unknown > 0 {
+		fmt.Fprintf(w, "\nDependents weren't counted for %d %s, so %s don't account for them; pass --dependents to count them (public repositories only).\n",
+			unknown, pluralize(unknown, "candidate", "candidates"), pluralize(unknown, "its score", "their scores"))
+	}
+	return nil
+}
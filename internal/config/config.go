@@ -0,0 +1,646 @@
+// ABOUTME: User configuration stored at $XDG_CONFIG_HOME/gh-repo-review/config.yml.
+// ABOUTME: Defaults, key bindings, theme and saved presets; saving edits only the presets section.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/query"
+	"github.com/user/gh-repo-review/internal/repo"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultInactiveThresholds are the day counts the inactivity filter cycles through
+var DefaultInactiveThresholds = []int{30, 90, 180, 365, 730}
+
+// DefaultCacheTTL is how long a cached repository list counts as fresh
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultKeys maps the remappable list view actions to their default keys
+var DefaultKeys = map[string]string{
+	"up":                "k",
+	"down":              "j",
+	"top":               "g",
+	"bottom":            "G",
+	"search":            "/",
+	"filter":            "f",
+	"details":           "l",
+	"select":            "x",
+	"select_all":        "A",
+	"select_violations": "P",
+	"deselect_all":      "D",
+	"archive":           "a",
+	"unarchive":         "U",
+	"undo":              "u",
+	"visibility":        "V",
+	"transfer":          "T",
+	"topics":            "t",
+	"edit":              "e",
+	"delete":            "d",
+	"open":              "o",
+	"sort":              "s",
+	"sort_direction":    "S",
+	"reload":            "r",
+	"owner":             "O",
+	"dry_run":           "p",
+	"audit_log":         "L",
+	"recommendations":   "R",
+	"help":              "?",
+	"quit":              "q",
+}
+
+// FixedKeys are the list view keys that can't be remapped; binding an
+// action to one of them would be shadowed by the built-in handler
+var FixedKeys = []string{
+	"ctrl+c", "enter", "esc", " ", "up", "down", "pgup", "pgdown", "home", "end",
+	"1", "2", "3", "4",
+}
+
+// Config is the parsed configuration file
+type Config struct {
+	// Defaults replaces the built-in filters, sort and search
+	Defaults Filter `yaml:"defaults"`
+	// InactiveThresholds are the day counts the inactivity filter cycles through
+	InactiveThresholds []int `yaml:"inactive_thresholds"`
+	// CacheTTL is how long a cached repository list counts as fresh
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// Keys remaps list view actions, e.g. archive: z
+	Keys map[string]string `yaml:"keys"`
+	// Theme overrides the palette
+	Theme Theme `yaml:"theme"`
+	// Delete sets how deletes are confirmed and which repos they refuse
+	Delete DeleteGuard `yaml:"delete"`
+	// Protect lists repos that bulk selection, archive and delete skip
+	Protect Protect `yaml:"protect"`
+	// ArchiveNotice is the README banner committed before archiving
+	ArchiveNotice ArchiveNotice `yaml:"archive_notice"`
+
+	Presets []Preset `yaml:"presets"`
+}
+
+// Delete confirmation modes
+const (
+	ConfirmTyped  = "typed"  // type the repo name, or the count for bulk deletes
+	ConfirmSimple = "simple" // a single y
+)
+
+// DeleteGuard protects popular repositories from deletion
+type DeleteGuard struct {
+	Confirm string `yaml:"confirm"`
+	// Repos above these limits need an explicit override; -1 disables a limit
+	MaxStars int `yaml:"max_stars"`
+	MaxForks int `yaml:"max_forks"`
+	// MaxDependents only applies where the dependents count could be fetched
+	MaxDependents int `yaml:"max_dependents"`
+}
+
+// DefaultDeleteGuard requires typed confirmation and protects repos with
+// more than 10 stars, 5 forks or any dependents
+var DefaultDeleteGuard = DeleteGuard{Confirm: ConfirmTyped, MaxStars: 10, MaxForks: 5, MaxDependents: 0}
+
+// Blockers explains why r may not be deleted without an override; nil
+// means it may
+func (g DeleteGuard) Blockers(r repo.Repo) []string {
+	var reasons []string
+	if g.MaxStars >= 0 && r.StargazerCount > g.MaxStars {
+		reasons = append(reasons, fmt.Sprintf("%d stars (limit %d)", r.StargazerCount, g.MaxStars))
+	}
+	if g.MaxForks >= 0 && r.ForkCount > g.MaxForks {
+		reasons = append(reasons, fmt.Sprintf("%d forks (limit %d)", r.ForkCount, g.MaxForks))
+	}
+	if g.MaxDependents >= 0 && r.Dependents > g.MaxDependents {
+		reasons = append(reasons, fmt.Sprintf("%d dependents (limit %d)", r.Dependents, g.MaxDependents))
+	}
+	return reasons
+}
+
+// Protect names repositories that must never be archived or deleted.
+// Repositories with a repo.ProtectMarker file are protected as well.
+type Protect struct {
+	// Repos are full names or glob patterns such as acme/prod-*
+	Repos  []string `yaml:"repos"`
+	Topics []string `yaml:"topics"`
+}
+
+// Reason explains why r is protected, or returns "" when it isn't
+func (p Protect) Reason(r repo.Repo) string {
+	name := strings.ToLower(r.FullName)
+	for _, pattern := range p.Repos {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return "matches " + pattern
+		}
+	}
+	for _, topic := range p.Topics {
+		if r.HasAnyTopic([]string{topic}) {
+			return "topic " + topic
+		}
+	}
+	if r.HasProtectMarker {
+		return "has " + repo.ProtectMarker
+	}
+	return ""
+}
+
+// Validate rejects malformed glob patterns
+func (p Protect) Validate() error {
+	for _, pattern := range p.Repos {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("protect.repos: invalid pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// ArchiveNotice is a banner prepended to README.md before a repo is archived.
+// Archived repos are read-only, so it has to be committed first.
+type ArchiveNotice struct {
+	// Enabled adds the notice unless it is turned off in the archive dialog
+	Enabled bool `yaml:"enabled"`
+	// Template is a Go template; .Repo is the full name and .MovedTo the
+	// optional new location
+	Template      string `yaml:"template"`
+	CommitMessage string `yaml:"commit_message"`
+}
+
+// DefaultArchiveNotice is off, with a banner saying the repo is archived
+var DefaultArchiveNotice = ArchiveNotice{
+	Template: "> [!WARNING]\n> This repository is archived and no longer maintained." +
+		"{{if .MovedTo}} It has moved to {{.MovedTo}}.{{end}}\n",
+	CommitMessage: "Add archive notice to README",
+}
+
+// Render returns the banner for fullName
+func (n ArchiveNotice) Render(fullName, movedTo string) (string, error) {
+	tmpl, err := template.New("archive_notice").Parse(n.Template)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	data := struct{ Repo, MovedTo string }{fullName, movedTo}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Validate checks that the template parses
+func (n ArchiveNotice) Validate() error {
+	if strings.TrimSpace(n.Template) == "" {
+		return fmt.Errorf("archive_notice.template must not be empty")
+	}
+	if _, err := template.New("archive_notice").Parse(n.Template); err != nil {
+		return fmt.Errorf("archive_notice.template: %w", err)
+	}
+	if strings.TrimSpace(n.CommitMessage) == "" {
+		return fmt.Errorf("archive_notice.commit_message must not be empty")
+	}
+	return nil
+}
+
+// Validate checks the confirmation mode and limits
+func (g DeleteGuard) Validate() error {
+	if g.Confirm != ConfirmTyped && g.Confirm != ConfirmSimple {
+		return fmt.Errorf("delete.confirm: unknown mode %q (want typed or simple)", g.Confirm)
+	}
+	if g.MaxStars < -1 || g.MaxForks < -1 || g.MaxDependents < -1 {
+		return fmt.Errorf("delete: limits must be -1 (no limit) or more")
+	}
+	return nil
+}
+
+// Theme holds colors as hex (#7C3AED) or ANSI 256 numbers; empty keeps the default
+type Theme struct {
+	Primary    string `yaml:"primary"`
+	Secondary  string `yaml:"secondary"`
+	Warning    string `yaml:"warning"`
+	Danger     string `yaml:"danger"`
+	Muted      string `yaml:"muted"`
+	Background string `yaml:"background"`
+	Foreground string `yaml:"foreground"`
+}
+
+// Preset is a named set of filters, sort order and search query
+type Preset struct {
+	Name   string `yaml:"name"`
+	Filter `yaml:",inline"`
+}
+
+// Filter is the file form of repo.FilterOptions
+type Filter struct {
+	ShowArchived  bool     `yaml:"show_archived"`
+	ShowPrivate   bool     `yaml:"show_private"`
+	ShowPublic    bool     `yaml:"show_public"`
+	ShowForks     bool     `yaml:"show_forks"`
+	OnlyInternal  bool     `yaml:"only_internal,omitempty"`
+	Language      string   `yaml:"language,omitempty"`
+	License       string   `yaml:"license,omitempty"`
+	MinStars      int      `yaml:"min_stars"`
+	MaxStars      int      `yaml:"max_stars"`
+	InactiveDays  int      `yaml:"inactive_days,omitempty"`
+	InactiveBy    string   `yaml:"inactive_by,omitempty"` // any, push, commit, issues, prs or release
+	Search        string   `yaml:"search,omitempty"`      // query language
+	Topics        []string `yaml:"topics,omitempty"`
+	ExcludeTopics []string `yaml:"exclude_topics,omitempty"`
+	Sort          string   `yaml:"sort"`
+	Descending    bool     `yaml:"descending"`
+}
+
+// UnmarshalYAML starts from the default filters so a preset only needs to
+// list what differs from them
+func (p *Preset) UnmarshalYAML(node *yaml.Node) error {
+	type plain Preset
+	v := plain{Filter: FromOptions(repo.DefaultFilterOptions())}
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+	*p = Preset(v)
+	return nil
+}
+
+// FromOptions converts filter options for saving
+func FromOptions(opts repo.FilterOptions) Filter {
+	search := opts.SearchQuery
+	if opts.Query != nil {
+		search = opts.Query.String()
+	}
+	return Filter{
+		ShowArchived:  opts.ShowArchived,
+		ShowPrivate:   opts.ShowPrivate,
+		ShowPublic:    opts.ShowPublic,
+		ShowForks:     opts.ShowForks,
+		OnlyInternal:  opts.OnlyInternal,
+		Language:      opts.Language,
+		License:       opts.License,
+		MinStars:      opts.MinStars,
+		MaxStars:      opts.MaxStars,
+		InactiveDays:  opts.InactiveForDays,
+		InactiveBy:    opts.InactiveBy.Name(),
+		Search:        search,
+		Topics:        opts.Topics,
+		ExcludeTopics: opts.ExcludeTopics,
+		Sort:          opts.SortBy.Name(),
+		Descending:    opts.SortDesc,
+	}
+}
+
+// Options converts the saved filter back into filter options
+func (f Filter) Options() (repo.FilterOptions, error) {
+	opts := repo.DefaultFilterOptions()
+	opts.ShowArchived = f.ShowArchived
+	opts.ShowPrivate = f.ShowPrivate
+	opts.ShowPublic = f.ShowPublic
+	opts.ShowForks = f.ShowForks
+	opts.OnlyInternal = f.OnlyInternal
+	opts.Language = f.Language
+	opts.License = f.License
+	opts.MinStars = f.MinStars
+	opts.MaxStars = f.MaxStars
+	opts.InactiveForDays = f.InactiveDays
+	opts.Topics = f.Topics
+	opts.ExcludeTopics = f.ExcludeTopics
+	opts.SortDesc = f.Descending
+
+	if f.InactiveBy != "" {
+		signal, err := repo.ParseActivitySignal(f.InactiveBy)
+		if err != nil {
+			return opts, err
+		}
+		opts.InactiveBy = signal
+	}
+
+	if f.Sort != "" {
+		sortBy, err := repo.ParseSortField(f.Sort)
+		if err != nil {
+			return opts, err
+		}
+		opts.SortBy = sortBy
+	}
+
+	if f.Search != "" {
+		q, err := query.Parse(f.Search)
+		if err != nil {
+			return opts, err
+		}
+		opts.SearchQuery = f.Search
+		opts.Query = q
+	}
+	return opts, nil
+}
+
+// Dir returns $XDG_CONFIG_HOME/gh-repo-review, falling back to
+// ~/.config/gh-repo-review
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh-repo-review"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gh-repo-review"), nil
+}
+
+// Path returns the location of config.yml
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yml"), nil
+}
+
+// New returns the built-in configuration
+func New() *Config {
+	return &Config{
+		Defaults:           FromOptions(repo.DefaultFilterOptions()),
+		InactiveThresholds: DefaultInactiveThresholds,
+		CacheTTL:           DefaultCacheTTL,
+		Delete:             DefaultDeleteGuard,
+		ArchiveNotice:      DefaultArchiveNotice,
+	}
+}
+
+// Load reads and validates the config file. Settings missing from the file,
+// or a missing file, keep their built-in values.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	cfg := New()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// DefaultOptions returns the configured default filter options
+func (c *Config) DefaultOptions() repo.FilterOptions {
+	opts, err := c.Defaults.Options()
+	if err != nil {
+		return repo.DefaultFilterOptions()
+	}
+	return opts
+}
+
+// InactiveCycle returns the inactivity filter values in cycle order, starting with 0 (off)
+func (c *Config) InactiveCycle() []int {
+	days := append([]int(nil), c.InactiveThresholds...)
+	sort.Ints(days)
+	return append([]int{0}, days...)
+}
+
+// KeyBindings returns the key for every remappable action
+func (c *Config) KeyBindings() map[string]string {
+	keys := make(map[string]string, len(DefaultKeys))
+	for action, key := range DefaultKeys {
+		keys[action] = key
+	}
+	for action, key := range c.Keys {
+		keys[action] = key
+	}
+	return keys
+}
+
+// Validate checks every setting, reporting the first problem found
+func (c *Config) Validate() error {
+	if _, err := c.Defaults.Options(); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+	for _, days := range c.InactiveThresholds {
+		if days <= 0 {
+			return fmt.Errorf("inactive_thresholds: %d is not a positive number of days", days)
+		}
+	}
+	if c.CacheTTL < 0 {
+		return fmt.Errorf("cache_ttl must not be negative")
+	}
+	if err := c.validateKeys(); err != nil {
+		return err
+	}
+	if err := c.Theme.Validate(); err != nil {
+		return err
+	}
+	if err := c.Delete.Validate(); err != nil {
+		return err
+	}
+	if err := c.Protect.Validate(); err != nil {
+		return err
+	}
+	if err := c.ArchiveNotice.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range c.Presets {
+		if p.Name == "" {
+			return fmt.Errorf("preset %d: missing name", i+1)
+		}
+		key := strings.ToLower(p.Name)
+		if seen[key] {
+			return fmt.Errorf("preset %q: duplicate name", p.Name)
+		}
+		seen[key] = true
+		if _, err := p.Options(); err != nil {
+			return fmt.Errorf("preset %q: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateKeys rejects unknown actions, fixed keys and keys bound to two actions
+func (c *Config) validateKeys() error {
+	fixed := make(map[string]bool, len(FixedKeys))
+	for _, key := range FixedKeys {
+		fixed[key] = true
+	}
+	for action, key := range c.Keys {
+		if _, ok := DefaultKeys[action]; !ok {
+			return fmt.Errorf("keys: unknown action %q", action)
+		}
+		if key == "" {
+			return fmt.Errorf("keys: %s: empty key", action)
+		}
+		if fixed[key] {
+			return fmt.Errorf("keys: %s: %q is a fixed key and can't be remapped", action, key)
+		}
+	}
+	owners := make(map[string]string)
+	actions := make([]string, 0, len(DefaultKeys))
+	for action := range DefaultKeys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	bindings := c.KeyBindings()
+	for _, action := range actions {
+		key := bindings[action]
+		if other, ok := owners[key]; ok {
+			return fmt.Errorf("keys: %q is bound to both %s and %s", key, other, action)
+		}
+		owners[key] = action
+	}
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate checks that every color is a hex color or an ANSI 256 number
+func (t Theme) Validate() error {
+	colors := []struct{ name, value string }{
+		{"primary", t.Primary},
+		{"secondary", t.Secondary},
+		{"warning", t.Warning},
+		{"danger", t.Danger},
+		{"muted", t.Muted},
+		{"background", t.Background},
+		{"foreground", t.Foreground},
+	}
+	for _, c := range colors {
+		if c.value == "" || hexColor.MatchString(c.value) {
+			continue
+		}
+		if n, err := strconv.Atoi(c.value); err == nil && n >= 0 && n <= 255 {
+			continue
+		}
+		return fmt.Errorf("theme: %s: invalid color %q (want #RRGGBB or 0-255)", c.name, c.value)
+	}
+	return nil
+}
+
+// Preset returns the preset with the given name, ignoring case
+func (c *Config) Preset(name string) (Preset, bool) {
+	for _, p := range c.Presets {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+// PresetOptions returns the filter options of a named preset
+func (c *Config) PresetOptions(name string) (repo.FilterOptions, error) {
+	p, ok := c.Preset(name)
+	if !ok {
+		var names []string
+		for _, p := range c.Presets {
+			names = append(names, p.Name)
+		}
+		if len(names) == 0 {
+			return repo.FilterOptions{}, fmt.Errorf("unknown preset %q (no presets saved)", name)
+		}
+		return repo.FilterOptions{}, fmt.Errorf("unknown preset %q (have %s)", name, strings.Join(names, ", "))
+	}
+	return p.Options()
+}
+
+// SetPreset adds or replaces a preset and saves the presets section
+func (c *Config) SetPreset(p Preset) error {
+	replaced := false
+	for i := range c.Presets {
+		if strings.EqualFold(c.Presets[i].Name, p.Name) {
+			c.Presets[i] = p
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		c.Presets = append(c.Presets, p)
+	}
+	return saveSection("presets", c.Presets)
+}
+
+// DeletePreset removes a preset and saves the presets section
+func (c *Config) DeletePreset(name string) error {
+	for i := range c.Presets {
+		if strings.EqualFold(c.Presets[i].Name, name) {
+			c.Presets = append(c.Presets[:i], c.Presets[i+1:]...)
+			return saveSection("presets", c.Presets)
+		}
+	}
+	return fmt.Errorf("unknown preset %q", name)
+}
+
+// saveSection replaces one top-level key in config.yml, leaving the rest
+// of the file, including comments, as it was
+func saveSection(key string, value interface{}) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level must be a mapping", path)
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1] = &valueNode
+			found = true
+			break
+		}
+	}
+	if !found {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &valueNode)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
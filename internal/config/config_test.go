@@ -0,0 +1,85 @@
+// ABOUTME: Tests for config validation, focused on remapped keys.
+// ABOUTME: Collisions must be caught whether they involve a default or another remapped key.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func TestValidateKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys map[string]string
+		want string
+	}{
+		{"defaults", nil, ""},
+		{"free key", map[string]string{"archive": "z"}, ""},
+		{"swap", map[string]string{"archive": "d", "delete": "a"}, ""},
+		{"unknown action", map[string]string{"explode": "z"}, `keys: unknown action "explode"`},
+		{"empty key", map[string]string{"archive": ""}, "keys: archive: empty key"},
+		{"collides with a default", map[string]string{"archive": "d"}, `keys: "d" is bound to both archive and delete`},
+		{"two remapped keys", map[string]string{"open": "z", "sort": "z"}, `keys: "z" is bound to both open and sort`},
+		{"moved default collides", map[string]string{"up": "j"}, `keys: "j" is bound to both down and up`},
+		{"fixed enter", map[string]string{"details": "enter"}, `keys: details: "enter" is a fixed key and can't be remapped`},
+		{"fixed arrow", map[string]string{"bottom": "down"}, `keys: bottom: "down" is a fixed key and can't be remapped`},
+		{"fixed space", map[string]string{"archive": " "}, `keys: archive: " " is a fixed key and can't be remapped`},
+		{"fixed toggle", map[string]string{"sort": "1"}, `keys: sort: "1" is a fixed key and can't be remapped`},
+		{"freed default", map[string]string{"help": "H", "search": "?"}, ""},
+		{"taken default", map[string]string{"search": "?"}, `keys: "?" is bound to both help and search`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.Keys = tt.keys
+			err := c.Validate()
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyBindings(t *testing.T) {
+	c := New()
+	c.Keys = map[string]string{"archive": "z"}
+	keys := c.KeyBindings()
+	if len(keys) != len(DefaultKeys) {
+		t.Fatalf("got %d bindings, want %d", len(keys), len(DefaultKeys))
+	}
+	if keys["archive"] != "z" || keys["delete"] != "d" {
+		t.Errorf("archive=%q delete=%q", keys["archive"], keys["delete"])
+	}
+	if DefaultKeys["archive"] != "a" {
+		t.Error("KeyBindings modified DefaultKeys")
+	}
+}
+
+func TestBlockers(t *testing.T) {
+	tests := []struct {
+		name  string
+		guard DeleteGuard
+		repo  repo.Repo
+		want  []string
+	}{
+		{"below limits", DefaultDeleteGuard, repo.Repo{StargazerCount: 10, ForkCount: 5, Dependents: 0}, nil},
+		{"stars and forks", DefaultDeleteGuard, repo.Repo{StargazerCount: 11, ForkCount: 6, Dependents: -1},
+			[]string{"11 stars (limit 10)", "6 forks (limit 5)"}},
+		{"dependents", DefaultDeleteGuard, repo.Repo{Dependents: 3}, []string{"3 dependents (limit 0)"}},
+		{"unknown dependents", DefaultDeleteGuard, repo.Repo{Dependents: -1}, nil},
+		{"limits off", DeleteGuard{MaxStars: -1, MaxForks: -1, MaxDependents: -1}, repo.Repo{StargazerCount: 900, ForkCount: 90, Dependents: 9}, nil},
+	}
+	for _, tt := range tests {
+		got := tt.guard.Blockers(tt.repo)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// ABOUTME: REST and GraphQL calls that keep the HTTP status and rate-limit headers.
+// ABOUTME: Failures are returned as *APIError so callers can tell rate limits from real errors.
+
+package gh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secondaryRateLimitWait is used when GitHub reports a secondary rate limit
+// without a Retry-After header, as its documentation recommends
+const secondaryRateLimitWait = time.Minute
+
+// APIError is a failed GitHub API request
+type APIError struct {
+	// StatusCode is the HTTP status, or 0 when no response was received
+	StatusCode int
+	Message    string
+	// RateLimitRemaining is -1 when the header was absent
+	RateLimitRemaining int
+	RateLimitReset     time.Time
+	RetryAfter         time.Duration
+	// Repeatable is set when sending the request twice is harmless, so a
+	// request that may have been applied before failing can be retried
+	Repeatable bool
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// IsRateLimit reports whether the request was refused by a primary or
+// secondary rate limit
+func (e *APIError) IsRateLimit() bool {
+	if e.StatusCode != 403 && e.StatusCode != 429 {
+		return false
+	}
+	return e.RateLimitRemaining == 0 || e.RetryAfter > 0 ||
+		strings.Contains(strings.ToLower(e.Message), "rate limit")
+}
+
+// RetryDelay reports how long to wait before retrying the request and
+// whether it is worth retrying at all. attempt starts at 1.
+func (e *APIError) RetryDelay(attempt int) (time.Duration, bool) {
+	if e.IsRateLimit() {
+		switch {
+		case e.RetryAfter > 0:
+			return e.RetryAfter, true
+		case e.RateLimitRemaining == 0 && !e.RateLimitReset.IsZero():
+			return time.Until(e.RateLimitReset) + time.Second, true
+		default:
+			return secondaryRateLimitWait, true
+		}
+	}
+
+	// No response or a server-side failure is usually transient, but the
+	// request may have been applied, so only repeatable ones are retried
+	if e.Repeatable && (e.StatusCode == 0 || e.StatusCode >= 500) {
+		return backoff(attempt), true
+	}
+	return 0, false
+}
+
+// repeatable reports whether a request can be sent again after it may have
+// been applied. Archive, visibility and description PATCHes and topic PUTs
+// set absolute values. A repeated DELETE fails with 404, a repeated transfer
+// POST or contents PUT may act twice.
+func repeatable(method, path string) bool {
+	switch method {
+	case "GET", "PATCH":
+		return true
+	case "PUT":
+		return !strings.Contains(path, "/contents/")
+	case "POST":
+		// GraphQL is only used for queries
+		return path == "graphql"
+	}
+	return false
+}
+
+// RetryDelay classifies any error returned by the client. Errors that are not
+// API errors, such as a missing gh binary, are never retried.
+func RetryDelay(err error, attempt int) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryDelay(attempt)
+	}
+	return 0, false
+}
+
+// backoff doubles from one second, capped at one minute
+func backoff(attempt int) time.Duration {
+	d := time.Second << (attempt - 1)
+	if d <= 0 || d > time.Minute {
+		return time.Minute
+	}
+	return d
+}
+
+// rest performs a REST request and returns the response body. params are
+// key=value query parameters, sent as strings; request bodies go through
+// restJSON.
+func (c *Client) rest(method, path string, params ...string) ([]byte, error) {
+	_, body, err := c.restResponse(method, path, params...)
+	return body, err
+}
+
+// restJSON performs a REST request with payload as the JSON body
+func (c *Client) restJSON(method, path string, payload interface{}) ([]byte, error) {
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	_, body, err := c.do(method, path, nil, input)
+	return body, err
+}
+
+// restResponse is rest that also returns the lower-cased response headers
+func (c *Client) restResponse(method, path string, params ...string) (map[string]string, []byte, error) {
+	return c.do(method, path, params, nil)
+}
+
+// graphql runs a GraphQL query and returns the response body
+func (c *Client) graphql(query string, variables map[string]interface{}) ([]byte, error) {
+	body, err := c.restJSON("POST", "graphql", map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return nil, err
+	}
+	if err := graphqlError(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// do sends a request over the native transport, or through `gh api` when
+// there is none, and turns failures into *APIError
+func (c *Client) do(method, path string, params []string, input []byte) (map[string]string, []byte, error) {
+	header, body, err := c.send(method, withQuery(path, params), input)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.Repeatable = repeatable(method, path)
+	}
+	return header, body, err
+}
+
+// send performs the request over whichever transport the client has
+func (c *Client) send(method, path string, input []byte) (map[string]string, []byte, error) {
+	if c.http != nil {
+		status, header, body, err := c.http.do(method, path, input)
+		if err != nil {
+			// No response: reported as status 0
+			return nil, nil, &APIError{Message: err.Error(), RateLimitRemaining: -1}
+		}
+		if status >= 200 && status < 300 {
+			return header, body, nil
+		}
+		return header, nil, newAPIError(method, path, status, header, body, "")
+	}
+	return ghRequest(method, path, input)
+}
+
+// ghRequest runs `gh api -i`, feeding input on stdin when set
+func ghRequest(method, path string, input []byte) (map[string]string, []byte, error) {
+	args := []string{"api", "-i", "-X", method, path}
+	if input != nil {
+		args = append(args, "--input", "-")
+	}
+
+	cmd := exec.Command("gh", args...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	status, header, body := parseResponse(stdout.Bytes())
+	if runErr == nil && status >= 200 && status < 300 {
+		return header, body, nil
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		// gh itself could not be started
+		return nil, nil, fmt.Errorf("failed to execute gh: %w", runErr)
+	}
+	return header, nil, newAPIError(method, path, status, header, body, strings.TrimSpace(stderr.String()))
+}
+
+// newAPIError builds the error for a failed response, preferring GitHub's
+// message over fallback
+func newAPIError(method, path string, status int, header map[string]string, body []byte, fallback string) *APIError {
+	apiErr := &APIError{
+		StatusCode:         status,
+		Message:            fallback,
+		RateLimitRemaining: -1,
+	}
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Message != "" {
+		apiErr.Message = payload.Message
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("%s %s failed", method, path)
+	}
+	if v, err := strconv.Atoi(header["x-ratelimit-remaining"]); err == nil {
+		apiErr.RateLimitRemaining = v
+	}
+	if v, err := strconv.ParseInt(header["x-ratelimit-reset"], 10, 64); err == nil {
+		apiErr.RateLimitReset = time.Unix(v, 0)
+	}
+	if v, err := strconv.Atoi(header["retry-after"]); err == nil {
+		apiErr.RetryAfter = time.Duration(v) * time.Second
+	}
+	return apiErr
+}
+
+var lastPageLink = regexp.MustCompile(`[?&]page=(\d+)[^>]*>;\s*rel="last"`)
+
+// lastPage returns the page number of the rel="last" entry of a Link header
+func lastPage(link string) (int, bool) {
+	m := lastPageLink.FindStringSubmatch(link)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+// parseResponse splits `gh api -i` output into status code, lower-cased
+// headers and body. Output without a status line yields status 0.
+func parseResponse(out []byte) (int, map[string]string, []byte) {
+	header := make(map[string]string)
+	r := bufio.NewReader(bytes.NewReader(out))
+
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "HTTP/") {
+		return 0, header, out
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return 0, header, out
+	}
+	status, _ := strconv.Atoi(fields[1])
+
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			header[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	body, _ := io.ReadAll(r)
+	return status, header, body
+}
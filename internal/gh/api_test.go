@@ -0,0 +1,59 @@
+// ABOUTME: Tests for API error classification: which failures are retried and after how long.
+// ABOUTME: Requests that may have been applied are only retried when repeating them is harmless.
+
+package gh
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepeatable(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "repos/o/r", true},
+		{"PATCH", "repos/o/r", true},
+		{"PUT", "repos/o/r/topics", true},
+		{"PUT", "repos/o/r/contents/README.md", false},
+		{"DELETE", "repos/o/r", false},
+		{"POST", "repos/o/r/transfer", false},
+		{"POST", "graphql", true},
+	}
+	for _, tt := range tests {
+		if got := repeatable(tt.method, tt.path); got != tt.want {
+			t.Errorf("repeatable(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		err       *APIError
+		wantRetry bool
+		wantMin   time.Duration
+	}{
+		{"retry-after", &APIError{StatusCode: 429, RetryAfter: 30 * time.Second, RateLimitRemaining: -1}, true, 30 * time.Second},
+		{"primary limit waits for reset", &APIError{StatusCode: 403, RateLimitRemaining: 0, RateLimitReset: reset}, true, 59 * time.Minute},
+		{"secondary limit without header", &APIError{StatusCode: 403, Message: "secondary rate limit", RateLimitRemaining: -1}, true, secondaryRateLimitWait},
+		{"forbidden is not a rate limit", &APIError{StatusCode: 403, Message: "forbidden", RateLimitRemaining: 10}, false, 0},
+		{"repeatable server error", &APIError{StatusCode: 500, RateLimitRemaining: -1, Repeatable: true}, true, time.Second},
+		{"unsafe server error", &APIError{StatusCode: 500, RateLimitRemaining: -1}, false, 0},
+		{"unsafe timeout", &APIError{RateLimitRemaining: -1}, false, 0},
+		{"not found", &APIError{StatusCode: 404, RateLimitRemaining: -1, Repeatable: true}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, retry := RetryDelay(tt.err, 1)
+			if retry != tt.wantRetry {
+				t.Fatalf("retry = %v, want %v", retry, tt.wantRetry)
+			}
+			if d < tt.wantMin {
+				t.Errorf("delay %s, want at least %s", d, tt.wantMin)
+			}
+		})
+	}
+}
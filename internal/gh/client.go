@@ -3,20 +3,28 @@ package gh
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/user/gh-repo-review/internal/repo"
 )
 
-// Client wraps the gh CLI for GitHub API operations
-type Client struct{}
+// Client performs GitHub API operations, natively when a token is
+// available and through the gh CLI otherwise
+type Client struct {
+	// http is nil when requests go through `gh api`
+	http *httpTransport
+}
 
 // NewClient creates a new GitHub client
 func NewClient() *Client {
-	return &Client{}
+	return &Client{http: nativeTransport()}
 }
 
 // ghResponse represents the raw JSON response from gh api
@@ -57,8 +65,14 @@ type listReposResponse struct {
 	} `json:"pageInfo"`
 }
 
-// CheckAuth verifies that gh is authenticated
+// CheckAuth verifies that gh is authenticated, or that the token works
 func (c *Client) CheckAuth() error {
+	if c.http != nil {
+		if _, err := c.rest("GET", "user"); err != nil {
+			return fmt.Errorf("authentication with the %s token failed: %w", c.http.host, err)
+		}
+		return nil
+	}
 	cmd := exec.Command("gh", "auth", "status")
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
@@ -70,28 +84,77 @@ func (c *Client) CheckAuth() error {
 
 // GetCurrentUser returns the authenticated user's login
 func (c *Client) GetCurrentUser() (string, error) {
-	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	output, err := cmd.Output()
+	body, err := c.rest("GET", "user")
 	if err != nil {
 		return "", fmt.Errorf("failed to get current user: %w", err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", fmt.Errorf("failed to parse current user: %w", err)
+	}
+	return user.Login, nil
 }
 
-// ListRepos fetches all repositories for the authenticated user
-func (c *Client) ListRepos() ([]repo.Repo, error) {
-	// Use GraphQL for efficient fetching with pagination
-	query := `
-query($cursor: String) {
-  viewer {
-    repositories(first: 100, after: $cursor, ownerAffiliations: [OWNER]) {
-      pageInfo {
-        hasNextPage
-        endCursor
-      }
-      nodes {
+// Repository affiliations accepted by ListOptions
+const (
+	AffiliationOwner        = "OWNER"
+	AffiliationCollaborator = "COLLABORATOR"
+	AffiliationOrgMember    = "ORGANIZATION_MEMBER"
+)
+
+// ListOptions selects whose repositories ListRepos returns
+type ListOptions struct {
+	// Owner is a user or organization login; empty means the authenticated user
+	Owner string
+	// Affiliations filters the authenticated user's repositories (default OWNER)
+	Affiliations []string
+}
+
+// ParseAffiliations converts a comma-separated list like "owner,collaborator"
+func ParseAffiliations(s string) ([]string, error) {
+	var affiliations []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		switch part {
+		case "":
+			continue
+		case AffiliationOwner, AffiliationCollaborator, AffiliationOrgMember:
+			affiliations = append(affiliations, part)
+		default:
+			return nil, fmt.Errorf("unknown affiliation %q (want owner, collaborator or organization_member)", part)
+		}
+	}
+	return affiliations, nil
+}
+
+// ListOrganizations returns the logins of organizations the user belongs to
+func (c *Client) ListOrganizations() ([]string, error) {
+	items, err := c.GetPaginated("user/orgs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	var orgs []string
+	for _, item := range items {
+		var org struct {
+			Login string `json:"login"`
+		}
+		if err := json.Unmarshal(item, &org); err != nil {
+			return nil, fmt.Errorf("failed to parse organizations: %w", err)
+		}
+		orgs = append(orgs, org.Login)
+	}
+	return orgs, nil
+}
+
+// repoNodeFields lists the repository fields fetched by ListRepos
+const repoNodeFields = `
         name
         nameWithOwner
+        owner {
+          login
+        }
         description
         url
         sshUrl
@@ -111,63 +174,203 @@ query($cursor: String) {
         updatedAt
         pushedAt
         diskUsage
+        licenseInfo {
+          spdxId
+          name
+        }
+        defaultBranchRef {
+          name
+          target {
+            ... on Commit {
+              authoredDate
+            }
+          }
+        }
+        latestIssue: issues(first: 1, orderBy: {field: UPDATED_AT, direction: DESC}) {
+          nodes {
+            updatedAt
+          }
+        }
+        latestPullRequest: pullRequests(first: 1, orderBy: {field: UPDATED_AT, direction: DESC}) {
+          nodes {
+            updatedAt
+          }
+        }
+        openPullRequests: pullRequests(states: OPEN) {
+          totalCount
+        }
+        releases(first: 1, orderBy: {field: CREATED_AT, direction: DESC}) {
+          nodes {
+            publishedAt
+            createdAt
+          }
+        }
+        homepageUrl
+        isMirror
+        isDisabled
+        isLocked
+        visibility
+        protectMarker: object(expression: "HEAD:` + repo.ProtectMarker + `") {
+          id
+        }
+        repositoryTopics(first: 20) {
+          nodes {
+            topic {
+              name
+            }
+          }
+        }`
+
+// viewerReposQuery lists the authenticated user's repositories
+const viewerReposQuery = `
+query($cursor: String, $affiliations: [RepositoryAffiliation]) {
+  viewer {
+    repositories(first: 100, after: $cursor, ownerAffiliations: $affiliations) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {` + repoNodeFields + `
       }
     }
   }
 }
 `
 
+// ownerReposQuery lists repositories owned by a user or organization
+const ownerReposQuery = `
+query($cursor: String, $owner: String!) {
+  repositoryOwner(login: $owner) {
+    repositories(first: 100, after: $cursor) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {` + repoNodeFields + `
+      }
+    }
+  }
+}
+`
+
+// repoConnection is one page of the repositories connection
+type repoConnection struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []struct {
+		Name     string `json:"name"`
+		FullName string `json:"nameWithOwner"`
+		Owner    struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		SSHURL      string `json:"sshUrl"`
+		IsPrivate   bool   `json:"isPrivate"`
+		IsArchived  bool   `json:"isArchived"`
+		IsFork      bool   `json:"isFork"`
+		IsTemplate  bool   `json:"isTemplate"`
+		Stargazers  int    `json:"stargazerCount"`
+		ForkCount   int    `json:"forkCount"`
+		Issues      struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"issues"`
+		PrimaryLanguage *struct {
+			Name string `json:"name"`
+		} `json:"primaryLanguage"`
+		CreatedAt   string `json:"createdAt"`
+		UpdatedAt   string `json:"updatedAt"`
+		PushedAt    string `json:"pushedAt"`
+		DiskUsage   int    `json:"diskUsage"`
+		LicenseInfo *struct {
+			SPDXID string `json:"spdxId"`
+			Name   string `json:"name"`
+		} `json:"licenseInfo"`
+		DefaultBranchRef *struct {
+			Name   string `json:"name"`
+			Target struct {
+				AuthoredDate string `json:"authoredDate"`
+			} `json:"target"`
+		} `json:"defaultBranchRef"`
+		LatestIssue struct {
+			Nodes []struct {
+				UpdatedAt string `json:"updatedAt"`
+			} `json:"nodes"`
+		} `json:"latestIssue"`
+		LatestPullRequest struct {
+			Nodes []struct {
+				UpdatedAt string `json:"updatedAt"`
+			} `json:"nodes"`
+		} `json:"latestPullRequest"`
+		OpenPullRequests struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"openPullRequests"`
+		Releases struct {
+			Nodes []struct {
+				PublishedAt string `json:"publishedAt"`
+				CreatedAt   string `json:"createdAt"`
+			} `json:"nodes"`
+		} `json:"releases"`
+		HomepageURL   string `json:"homepageUrl"`
+		IsMirror      bool   `json:"isMirror"`
+		IsDisabled    bool   `json:"isDisabled"`
+		IsLocked      bool   `json:"isLocked"`
+		Visibility    string `json:"visibility"`
+		ProtectMarker *struct {
+			ID string `json:"id"`
+		} `json:"protectMarker"`
+		RepositoryTopics struct {
+			Nodes []struct {
+				Topic struct {
+					Name string `json:"name"`
+				} `json:"topic"`
+			} `json:"nodes"`
+		} `json:"repositoryTopics"`
+	} `json:"nodes"`
+}
+
+// ListRepos fetches all repositories for the authenticated user or the given owner
+func (c *Client) ListRepos(opts ListOptions) ([]repo.Repo, error) {
+	// Use GraphQL for efficient fetching with pagination
+	query := viewerReposQuery
+	if opts.Owner != "" {
+		query = ownerReposQuery
+	}
+
+	affiliations := opts.Affiliations
+	if len(affiliations) == 0 {
+		affiliations = []string{AffiliationOwner}
+	}
+
 	var allRepos []repo.Repo
 	cursor := ""
 
 	for {
-		args := []string{"api", "graphql", "-f", fmt.Sprintf("query=%s", query)}
+		variables := map[string]interface{}{}
 		if cursor != "" {
-			args = append(args, "-f", fmt.Sprintf("cursor=%s", cursor))
+			variables["cursor"] = cursor
+		}
+		if opts.Owner != "" {
+			variables["owner"] = opts.Owner
+		} else {
+			variables["affiliations"] = affiliations
 		}
 
-		cmd := exec.Command("gh", args...)
-		output, err := cmd.Output()
+		output, err := c.graphql(query, variables)
 		if err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				return nil, fmt.Errorf("gh api failed: %s", string(exitErr.Stderr))
-			}
-			return nil, fmt.Errorf("failed to execute gh: %w", err)
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
 		}
 
 		var result struct {
 			Data struct {
-				Viewer struct {
-					Repositories struct {
-						PageInfo struct {
-							HasNextPage bool   `json:"hasNextPage"`
-							EndCursor   string `json:"endCursor"`
-						} `json:"pageInfo"`
-						Nodes []struct {
-							Name        string `json:"name"`
-							FullName    string `json:"nameWithOwner"`
-							Description string `json:"description"`
-							URL         string `json:"url"`
-							SSHURL      string `json:"sshUrl"`
-							IsPrivate   bool   `json:"isPrivate"`
-							IsArchived  bool   `json:"isArchived"`
-							IsFork      bool   `json:"isFork"`
-							IsTemplate  bool   `json:"isTemplate"`
-							Stargazers  int    `json:"stargazerCount"`
-							ForkCount   int    `json:"forkCount"`
-							Issues      struct {
-								TotalCount int `json:"totalCount"`
-							} `json:"issues"`
-							PrimaryLanguage *struct {
-								Name string `json:"name"`
-							} `json:"primaryLanguage"`
-							CreatedAt string `json:"createdAt"`
-							UpdatedAt string `json:"updatedAt"`
-							PushedAt  string `json:"pushedAt"`
-							DiskUsage int    `json:"diskUsage"`
-						} `json:"nodes"`
-					} `json:"repositories"`
+				Viewer *struct {
+					Repositories repoConnection `json:"repositories"`
 				} `json:"viewer"`
+				RepositoryOwner *struct {
+					Repositories repoConnection `json:"repositories"`
+				} `json:"repositoryOwner"`
 			} `json:"data"`
 		}
 
@@ -175,7 +378,19 @@ query($cursor: String) {
 			return nil, fmt.Errorf("failed to parse response: %w", err)
 		}
 
-		for _, r := range result.Data.Viewer.Repositories.Nodes {
+		var conn repoConnection
+		switch {
+		case result.Data.Viewer != nil:
+			conn = result.Data.Viewer.Repositories
+		case result.Data.RepositoryOwner != nil:
+			conn = result.Data.RepositoryOwner.Repositories
+		case opts.Owner == "":
+			return nil, fmt.Errorf("failed to list repositories: no viewer in response")
+		default:
+			return nil, fmt.Errorf("owner %q not found", opts.Owner)
+		}
+
+		for _, r := range conn.Nodes {
 			createdAt, _ := time.Parse(time.RFC3339, r.CreatedAt)
 			updatedAt, _ := time.Parse(time.RFC3339, r.UpdatedAt)
 			pushedAt, _ := time.Parse(time.RFC3339, r.PushedAt)
@@ -185,31 +400,81 @@ query($cursor: String) {
 				lang = r.PrimaryLanguage.Name
 			}
 
+			var topics []string
+			for _, t := range r.RepositoryTopics.Nodes {
+				topics = append(topics, t.Topic.Name)
+			}
+
+			// Licenses GitHub can't identify have spdxId NOASSERTION
+			license := ""
+			if r.LicenseInfo != nil {
+				license = r.LicenseInfo.SPDXID
+				if license == "" || license == "NOASSERTION" {
+					license = r.LicenseInfo.Name
+				}
+			}
+			branch := ""
+			var lastCommit, lastIssue, lastPR, lastRelease time.Time
+			if r.DefaultBranchRef != nil {
+				branch = r.DefaultBranchRef.Name
+				lastCommit, _ = time.Parse(time.RFC3339, r.DefaultBranchRef.Target.AuthoredDate)
+			}
+			if len(r.LatestIssue.Nodes) > 0 {
+				lastIssue, _ = time.Parse(time.RFC3339, r.LatestIssue.Nodes[0].UpdatedAt)
+			}
+			if len(r.LatestPullRequest.Nodes) > 0 {
+				lastPR, _ = time.Parse(time.RFC3339, r.LatestPullRequest.Nodes[0].UpdatedAt)
+			}
+			if len(r.Releases.Nodes) > 0 {
+				// Drafts have no publishedAt
+				rel := r.Releases.Nodes[0]
+				if lastRelease, _ = time.Parse(time.RFC3339, rel.PublishedAt); lastRelease.IsZero() {
+					lastRelease, _ = time.Parse(time.RFC3339, rel.CreatedAt)
+				}
+			}
+
 			allRepos = append(allRepos, repo.Repo{
-				Name:            r.Name,
-				FullName:        r.FullName,
-				Description:     r.Description,
-				URL:             r.URL,
-				SSHURL:          r.SSHURL,
-				IsPrivate:       r.IsPrivate,
-				IsArchived:      r.IsArchived,
-				IsFork:          r.IsFork,
-				IsTemplate:      r.IsTemplate,
-				StargazerCount:  r.Stargazers,
-				ForkCount:       r.ForkCount,
-				OpenIssuesCount: r.Issues.TotalCount,
-				PrimaryLanguage: lang,
-				CreatedAt:       createdAt,
-				UpdatedAt:       updatedAt,
-				PushedAt:        pushedAt,
-				DiskUsage:       r.DiskUsage,
+				Name:             r.Name,
+				FullName:         r.FullName,
+				Owner:            r.Owner.Login,
+				Description:      r.Description,
+				URL:              r.URL,
+				SSHURL:           r.SSHURL,
+				IsPrivate:        r.IsPrivate,
+				IsArchived:       r.IsArchived,
+				IsFork:           r.IsFork,
+				IsTemplate:       r.IsTemplate,
+				StargazerCount:   r.Stargazers,
+				ForkCount:        r.ForkCount,
+				OpenIssuesCount:  r.Issues.TotalCount,
+				PrimaryLanguage:  lang,
+				CreatedAt:        createdAt,
+				UpdatedAt:        updatedAt,
+				PushedAt:         pushedAt,
+				DiskUsage:        r.DiskUsage,
+				Topics:           topics,
+				License:          license,
+				DefaultBranch:    branch,
+				HomepageURL:      r.HomepageURL,
+				IsMirror:         r.IsMirror,
+				IsDisabled:       r.IsDisabled,
+				IsLocked:         r.IsLocked,
+				Visibility:       r.Visibility,
+				HasProtectMarker: r.ProtectMarker != nil,
+				LastCommitAt:     lastCommit,
+				LastIssueAt:      lastIssue,
+				LastPRAt:         lastPR,
+				LastReleaseAt:    lastRelease,
+				OpenPRCount:      r.OpenPullRequests.TotalCount,
+				Contributors:     -1,
+				Dependents:       -1,
 			})
 		}
 
-		if !result.Data.Viewer.Repositories.PageInfo.HasNextPage {
+		if !conn.PageInfo.HasNextPage {
 			break
 		}
-		cursor = result.Data.Viewer.Repositories.PageInfo.EndCursor
+		cursor = conn.PageInfo.EndCursor
 	}
 
 	return allRepos, nil
@@ -217,37 +482,215 @@ query($cursor: String) {
 
 // ArchiveRepo archives a repository
 func (c *Client) ArchiveRepo(fullName string) error {
-	cmd := exec.Command("gh", "repo", "archive", fullName, "--yes")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to archive %s: %s", fullName, stderr.String())
+	if _, err := c.restJSON("PATCH", "repos/"+fullName, map[string]bool{"archived": true}); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", fullName, err)
 	}
 	return nil
 }
 
 // UnarchiveRepo unarchives a repository
 func (c *Client) UnarchiveRepo(fullName string) error {
-	cmd := exec.Command("gh", "repo", "unarchive", fullName, "--yes")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to unarchive %s: %s", fullName, stderr.String())
+	if _, err := c.restJSON("PATCH", "repos/"+fullName, map[string]bool{"archived": false}); err != nil {
+		return fmt.Errorf("failed to unarchive %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// SetVisibility makes a repository public, private or internal. Making a
+// public repository private detaches its forks and removes its stars.
+func (c *Client) SetVisibility(fullName, visibility string) error {
+	if _, err := c.restJSON("PATCH", "repos/"+fullName, map[string]string{"visibility": visibility}); err != nil {
+		return fmt.Errorf("failed to make %s %s: %w", fullName, visibility, err)
+	}
+	return nil
+}
+
+// RepoUpdate holds the repository settings UpdateRepo changes; nil fields
+// are left alone
+type RepoUpdate struct {
+	Description *string `json:"description,omitempty"`
+	Homepage    *string `json:"homepage,omitempty"`
+}
+
+// UpdateRepo changes a repository's description and homepage
+func (c *Client) UpdateRepo(fullName string, update RepoUpdate) error {
+	if _, err := c.restJSON("PATCH", "repos/"+fullName, update); err != nil {
+		return fmt.Errorf("failed to update %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// TransferRepo moves a repository to another user or organization. A
+// non-empty newName renames it on the way; teamIDs give teams of the new
+// organization access. GitHub completes the transfer asynchronously.
+func (c *Client) TransferRepo(fullName, newOwner, newName string, teamIDs []int) error {
+	payload := struct {
+		NewOwner string `json:"new_owner"`
+		NewName  string `json:"new_name,omitempty"`
+		TeamIDs  []int  `json:"team_ids,omitempty"`
+	}{newOwner, newName, teamIDs}
+	if _, err := c.restJSON("POST", "repos/"+fullName+"/transfer", payload); err != nil {
+		return fmt.Errorf("failed to transfer %s to %s: %w", fullName, newOwner, err)
 	}
 	return nil
 }
 
+// GetTopics returns a repository's topics
+func (c *Client) GetTopics(fullName string) ([]string, error) {
+	body, err := c.rest("GET", "repos/"+fullName+"/topics")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topics of %s: %w", fullName, err)
+	}
+	var result struct {
+		Names []string `json:"names"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse topics of %s: %w", fullName, err)
+	}
+	return result.Names, nil
+}
+
+// SetTopics replaces a repository's topics
+func (c *Client) SetTopics(fullName string, topics []string) error {
+	// Topics such as 2024 must stay strings, and an empty list clears them all
+	payload := struct {
+		Names []string `json:"names"`
+	}{Names: append([]string{}, topics...)}
+	if _, err := c.restJSON("PUT", "repos/"+fullName+"/topics", payload); err != nil {
+		return fmt.Errorf("failed to set topics of %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// EditTopics adds and removes topics, keeping the repository's others. The
+// current topics are fetched first so concurrent edits elsewhere survive.
+func (c *Client) EditTopics(fullName string, add, remove []string) error {
+	current, err := c.GetTopics(fullName)
+	if err != nil {
+		return err
+	}
+	return c.SetTopics(fullName, repo.EditTopics(current, add, remove))
+}
+
+// AddTopics adds topics, keeping the repository's others
+func (c *Client) AddTopics(fullName string, topics ...string) error {
+	return c.EditTopics(fullName, topics, nil)
+}
+
 // DeleteRepo deletes a repository (dangerous!)
 func (c *Client) DeleteRepo(fullName string) error {
-	cmd := exec.Command("gh", "repo", "delete", fullName, "--yes")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to delete %s: %s", fullName, stderr.String())
+	if _, err := c.rest("DELETE", "repos/"+fullName); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", fullName, err)
 	}
 	return nil
 }
 
+// GetContributorCount returns the number of contributors, anonymous ones
+// included. With one contributor per page the last page number is the count.
+func (c *Client) GetContributorCount(fullName string) (int, error) {
+	header, body, err := c.restResponse("GET", "repos/"+fullName+"/contributors", "per_page=1", "anon=true")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contributors of %s: %w", fullName, err)
+	}
+	if n, ok := lastPage(header["link"]); ok {
+		return n, nil
+	}
+	// Empty repositories answer 204 without a body
+	if len(bytes.TrimSpace(body)) == 0 {
+		return 0, nil
+	}
+	var page []json.RawMessage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return 0, fmt.Errorf("failed to parse contributors of %s: %w", fullName, err)
+	}
+	return len(page), nil
+}
+
+// countWorkers bounds parallel contributor and dependents requests
+const countWorkers = 8
+
+// GetContributorCounts counts contributors for several repositories in
+// parallel. Repositories whose count fails are left out.
+func (c *Client) GetContributorCounts(fullNames []string) map[string]int {
+	return countEach(fullNames, c.GetContributorCount)
+}
+
+// dependentsCount finds the repository count on the dependents page, e.g.
+// "1,234 Repositories"
+var dependentsCount = regexp.MustCompile(`([0-9][0-9,]*)\s+Repositor(?:y|ies)\b`)
+
+// parseDependents reads the number of dependent repositories from the HTML
+// of a network/dependents page
+func parseDependents(page []byte) (int, bool) {
+	match := dependentsCount.FindSubmatch(page)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(string(match[1]), ",", ""))
+	return n, err == nil
+}
+
+// GetDependentsCount returns how many repositories depend on fullName. The
+// API doesn't expose dependents, so the count is read from the dependency
+// graph's network/dependents page, which is only public for public
+// repositories with the dependency graph enabled.
+func (c *Client) GetDependentsCount(fullName string) (int, error) {
+	page, err := webPage(fullName + "/network/dependents")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count dependents of %s: %w", fullName, err)
+	}
+	n, ok := parseDependents(page)
+	if !ok {
+		return 0, fmt.Errorf("no dependents count for %s; is the dependency graph enabled?", fullName)
+	}
+	return n, nil
+}
+
+// GetDependentsCounts counts dependents for several repositories in
+// parallel. Repositories whose count fails are left out.
+func (c *Client) GetDependentsCounts(fullNames []string) map[string]int {
+	return countEach(fullNames, c.GetDependentsCount)
+}
+
+// countEach runs count for every name in parallel, leaving out failures
+func countEach(fullNames []string, count func(fullName string) (int, error)) map[string]int {
+	counts := make(map[string]int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, countWorkers)
+	for _, name := range fullNames {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			n, err := count(name)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			counts[name] = n
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return counts
+}
+
+// HasProtectMarker reports whether the repository's default branch has a
+// repo.ProtectMarker file in its root
+func (c *Client) HasProtectMarker(fullName string) (bool, error) {
+	_, err := c.rest("GET", "repos/"+fullName+"/contents/"+repo.ProtectMarker)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s for %s: %w", fullName, repo.ProtectMarker, err)
+	}
+	return true, nil
+}
+
 // OpenInBrowser opens the repository in the default browser
 func (c *Client) OpenInBrowser(fullName string) error {
 	cmd := exec.Command("gh", "repo", "view", fullName, "--web")
@@ -256,8 +699,7 @@ func (c *Client) OpenInBrowser(fullName string) error {
 
 // GetRepoStats returns detailed stats for a repo
 func (c *Client) GetRepoStats(fullName string) (map[string]interface{}, error) {
-	cmd := exec.Command("gh", "api", fmt.Sprintf("repos/%s", fullName))
-	output, err := cmd.Output()
+	output, err := c.rest("GET", "repos/"+fullName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo stats: %w", err)
 	}
@@ -269,3 +711,30 @@ func (c *Client) GetRepoStats(fullName string) (map[string]interface{}, error) {
 
 	return stats, nil
 }
+
+// GetPaginated fetches every page of a REST list endpoint such as
+// "repos/owner/name/issues?state=all" and returns the raw items
+func (c *Client) GetPaginated(path string) ([]json.RawMessage, error) {
+	var fields []string
+	if !strings.Contains(path, "per_page=") {
+		fields = []string{"per_page=100"}
+	}
+	var items []json.RawMessage
+	next := path
+	for next != "" {
+		header, body, err := c.restResponse("GET", next, fields...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		items = append(items, page...)
+
+		// The next link already carries the query parameters
+		next, _ = nextLink(header["link"])
+		fields = nil
+	}
+	return items, nil
+}
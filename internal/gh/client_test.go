@@ -0,0 +1,34 @@
+// ABOUTME: Tests for client helpers that don't need a server, such as
+// ABOUTME: reading the dependents count from a network/dependents page.
+
+package gh
+
+import "testing"
+
+func TestParseDependents(t *testing.T) {
+	tests := []struct {
+		name   string
+		page   string
+		want   int
+		wantOK bool
+	}{
+		{"thousands", `<a class="btn-link selected" href="/o/r/network/dependents?dependent_type=REPOSITORY">
+        <svg aria-hidden="true" height="16"></svg>
+        1,234
+        Repositories
+</a>
+<a class="btn-link" href="/o/r/network/dependents?dependent_type=PACKAGE">
+        56
+        Packages
+</a>`, 1234, true},
+		{"single", "<a>\n  1\n  Repository\n</a>", 1, true},
+		{"none", "<a>0\nRepositories</a>", 0, true},
+		{"graph disabled", "<p>Dependency graph is not enabled for this repository.</p>", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseDependents([]byte(tt.page))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: got %d %v, want %d %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// ABOUTME: Commits a notice to the top of a repository's README.md through the contents API.
+// ABOUTME: Used to mark repositories as archived before they become read-only.
+
+package gh
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// readmePath is the file the notice goes into
+const readmePath = "README.md"
+
+// PrependReadme commits notice to the top of README.md on the default
+// branch, creating the file when it is missing. A README that already
+// starts with the notice is left alone, so retries don't add it twice.
+func (c *Client) PrependReadme(fullName, notice, commitMessage string) error {
+	var current struct {
+		SHA     string `json:"sha"`
+		Content string `json:"content"`
+	}
+	body, err := c.rest("GET", "repos/"+fullName+"/contents/"+readmePath)
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 404:
+		// No README yet, or an empty repository: create it
+	case err != nil:
+		return fmt.Errorf("failed to read README of %s: %w", fullName, err)
+	default:
+		if err := json.Unmarshal(body, &current); err != nil {
+			return fmt.Errorf("failed to parse README of %s: %w", fullName, err)
+		}
+	}
+
+	// The API wraps base64 content at 60 columns
+	existing, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(current.Content, "\n", ""))
+	if err != nil {
+		return fmt.Errorf("failed to decode README of %s: %w", fullName, err)
+	}
+	if strings.HasPrefix(string(existing), notice) {
+		return nil
+	}
+	content := notice
+	if len(existing) > 0 {
+		content = strings.TrimRight(notice, "\n") + "\n\n" + string(existing)
+	}
+
+	payload := map[string]string{
+		"message": commitMessage,
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+	if current.SHA != "" {
+		payload["sha"] = current.SHA
+	}
+	if _, err := c.restJSON("PUT", "repos/"+fullName+"/contents/"+readmePath, payload); err != nil {
+		return fmt.Errorf("failed to add notice to README of %s: %w", fullName, err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// ABOUTME: Native HTTP transport for the REST and GraphQL APIs using gh's stored token.
+// ABOUTME: Without a token, or with GH_REPO_REVIEW_TRANSPORT=gh, requests fall back to `gh api`.
+
+package gh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHost is used when GH_HOST is unset
+const defaultHost = "github.com"
+
+// requestTimeout bounds a single API request
+const requestTimeout = 2 * time.Minute
+
+// httpTransport talks to the GitHub API directly
+type httpTransport struct {
+	client *http.Client
+	host   string
+	token  string
+	// restURL ends in a slash; API paths are appended to it
+	restURL    string
+	graphqlURL string
+}
+
+// newHTTPTransport creates the transport for github.com or a GitHub
+// Enterprise Server host
+func newHTTPTransport(host, token string) *httpTransport {
+	t := &httpTransport{
+		client:     &http.Client{Timeout: requestTimeout},
+		host:       host,
+		token:      token,
+		restURL:    "https://api.github.com/",
+		graphqlURL: "https://api.github.com/graphql",
+	}
+	if host != defaultHost {
+		t.restURL = "https://" + host + "/api/v3/"
+		t.graphqlURL = "https://" + host + "/api/graphql"
+	}
+	return t
+}
+
+var (
+	sharedTransport *httpTransport
+	transportOnce   sync.Once
+)
+
+// nativeTransport returns the process-wide HTTP transport, or nil when the
+// gh subprocess should be used instead. The token is looked up only once.
+func nativeTransport() *httpTransport {
+	transportOnce.Do(func() {
+		if os.Getenv("GH_REPO_REVIEW_TRANSPORT") == "gh" {
+			return
+		}
+		host := os.Getenv("GH_HOST")
+		if host == "" {
+			host = defaultHost
+		}
+		token := lookupToken(host)
+		if token == "" {
+			return
+		}
+		sharedTransport = newHTTPTransport(host, token)
+	})
+	return sharedTransport
+}
+
+// lookupToken finds a token the way gh does: environment variables first,
+// then gh's own credential store
+func lookupToken(host string) string {
+	vars := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != defaultHost {
+		vars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, v := range vars {
+		if token := os.Getenv(v); token != "" {
+			return token
+		}
+	}
+	out, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// url returns the endpoint for an API path such as "repos/o/r" or "graphql"
+func (t *httpTransport) url(path string) string {
+	switch {
+	case strings.HasPrefix(path, "https://"), strings.HasPrefix(path, "http://"):
+		// Link headers carry absolute URLs
+		return path
+	case path == "graphql":
+		return t.graphqlURL
+	}
+	return t.restURL + path
+}
+
+// do performs a request with input, when set, as the JSON body. The error
+// is a transport failure; HTTP errors are reported through the status.
+func (t *httpTransport) do(method, path string, input []byte) (int, map[string]string, []byte, error) {
+	var reader io.Reader
+	if input != nil {
+		reader = bytes.NewReader(input)
+	}
+	req, err := http.NewRequest(method, t.url(path), reader)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header.Set("Authorization", "token "+t.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "gh-repo-review")
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	header := make(map[string]string)
+	for key, values := range resp.Header {
+		header[strings.ToLower(key)] = strings.Join(values, ", ")
+	}
+	return resp.StatusCode, header, respBody, nil
+}
+
+// maxWebPage bounds how much of a website page is read
+const maxWebPage = 4 << 20
+
+// webPage fetches a page of the GitHub website, such as "o/r/network/dependents".
+// The website doesn't accept API tokens, so only public pages can be read.
+func webPage(path string) ([]byte, error) {
+	host := os.Getenv("GH_HOST")
+	if host == "" {
+		host = defaultHost
+	}
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Get("https://" + host + "/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", path, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxWebPage))
+}
+
+// withQuery adds key=value params to the query string of path
+func withQuery(path string, params []string) string {
+	if len(params) == 0 {
+		return path
+	}
+	query := url.Values{}
+	for _, p := range params {
+		key, value, _ := strings.Cut(p, "=")
+		query.Add(key, value)
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + query.Encode()
+}
+
+// nextLink returns the rel="next" URL of a Link header
+func nextLink(link string) (string, bool) {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>"), true
+		}
+	}
+	return "", false
+}
+
+// graphQLErrors is the error list of a GraphQL response
+type graphQLErrors struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphqlError returns the errors GitHub reported with a 200 response, or
+// nil when there are none
+func graphqlError(body []byte) error {
+	var result graphQLErrors
+	if json.Unmarshal(body, &result) != nil || len(result.Errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, e := range result.Errors {
+		messages = append(messages, e.Message)
+	}
+	return &APIError{
+		StatusCode:         http.StatusOK,
+		Message:            fmt.Sprintf("GraphQL: %s", strings.Join(messages, "; ")),
+		RateLimitRemaining: -1,
+	}
+}
@@ -0,0 +1,159 @@
+// ABOUTME: Tests for the native HTTP transport against a local server.
+// ABOUTME: Checks typed JSON bodies, query parameters, pagination and typed errors.
+
+package gh
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// request is what the test server received
+type request struct {
+	Method, Path, Query, Auth string
+	Body                      map[string]interface{}
+}
+
+// testClient returns a client whose native transport talks to handler
+func testClient(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) (*Client, *[]request) {
+	var got []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Auth: r.Header.Get("Authorization")}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.Body); err != nil {
+				t.Errorf("%s %s: body is not a JSON object: %s", r.Method, r.URL.Path, data)
+			}
+		}
+		got = append(got, req)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	transport := newHTTPTransport("github.example.com", "secret")
+	transport.client = srv.Client()
+	transport.restURL = srv.URL + "/api/v3/"
+	transport.graphqlURL = srv.URL + "/api/graphql"
+	return &Client{http: transport}, &got
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{}`))
+}
+
+func TestNewHTTPTransportHosts(t *testing.T) {
+	tests := []struct {
+		host, rest, graphql string
+	}{
+		{"github.com", "https://api.github.com/repos/o/r", "https://api.github.com/graphql"},
+		{"ghe.example.com", "https://ghe.example.com/api/v3/repos/o/r", "https://ghe.example.com/api/graphql"},
+	}
+	for _, tt := range tests {
+		tr := newHTTPTransport(tt.host, "token")
+		if got := tr.url("repos/o/r"); got != tt.rest {
+			t.Errorf("%s REST: got %s, want %s", tt.host, got, tt.rest)
+		}
+		if got := tr.url("graphql"); got != tt.graphql {
+			t.Errorf("%s GraphQL: got %s, want %s", tt.host, got, tt.graphql)
+		}
+	}
+}
+
+func TestTypedBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want map[string]interface{}
+	}{
+		{"archive", func(c *Client) error { return c.ArchiveRepo("o/r") },
+			map[string]interface{}{"archived": true}},
+		{"visibility", func(c *Client) error { return c.SetVisibility("o/r", "private") },
+			map[string]interface{}{"visibility": "private"}},
+		{"numeric and boolean topics stay strings", func(c *Client) error { return c.SetTopics("o/r", []string{"2024", "true", "go"}) },
+			map[string]interface{}{"names": []interface{}{"2024", "true", "go"}}},
+		{"clearing topics sends an empty list", func(c *Client) error { return c.SetTopics("o/r", nil) },
+			map[string]interface{}{"names": []interface{}{}}},
+		{"numeric transfer name stays a string", func(c *Client) error { return c.TransferRepo("o/r", "1234", "2048", []int{7}) },
+			map[string]interface{}{"new_owner": "1234", "new_name": "2048", "team_ids": []interface{}{7.0}}},
+		{"description that looks like a number", func(c *Client) error {
+			d := "42"
+			return c.UpdateRepo("o/r", RepoUpdate{Description: &d})
+		}, map[string]interface{}{"description": "42"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, got := testClient(t, ok)
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			if len(*got) != 1 {
+				t.Fatalf("got %d requests, want 1", len(*got))
+			}
+			req := (*got)[0]
+			if req.Auth != "token secret" {
+				t.Errorf("Authorization %q", req.Auth)
+			}
+			if !reflect.DeepEqual(req.Body, tt.want) {
+				t.Errorf("body %v, want %v", req.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginationAndQuery(t *testing.T) {
+	var base string
+	c, got := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+base+`/api/v3/user/orgs?per_page=100&page=2>; rel="next"`)
+			w.Write([]byte(`[{"login":"one"}]`))
+			return
+		}
+		w.Write([]byte(`[{"login":"two"}]`))
+	})
+	base = strings.TrimSuffix(c.http.restURL, "/api/v3/")
+
+	orgs, err := c.ListOrganizations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(orgs, []string{"one", "two"}) {
+		t.Errorf("got %v", orgs)
+	}
+	// The next link is followed as given, without adding per_page again
+	if (*got)[0].Query != "per_page=100" || (*got)[1].Query != "per_page=100&page=2" {
+		t.Errorf("queries %q and %q", (*got)[0].Query, (*got)[1].Query)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	c, _ := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/graphql":
+			w.Write([]byte(`{"errors":[{"message":"Could not resolve to a User"}]}`))
+		default:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1900000000")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+		}
+	})
+
+	var apiErr *APIError
+	err := c.DeleteRepo("o/r")
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != 403 || apiErr.RateLimitRemaining != 0 || !apiErr.IsRateLimit() || apiErr.Repeatable {
+		t.Errorf("got %+v", apiErr)
+	}
+
+	_, err = c.ListRepos(ListOptions{Owner: "nobody"})
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "Could not resolve") {
+		t.Errorf("GraphQL error: got %v", err)
+	}
+}
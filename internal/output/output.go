@@ -0,0 +1,267 @@
+// ABOUTME: Renders repository listings as table, JSON, NDJSON, CSV, TSV or a Go template.
+// ABOUTME: Records include derived values so consumers don't have to recompute them.
+
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// Format names an output renderer
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+	FormatTSV    Format = "tsv"
+)
+
+// Formats lists the supported formats in help order
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON, FormatCSV, FormatTSV}
+
+// ParseFormat validates a format name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (want table, json, ndjson, csv or tsv)", name)
+}
+
+// Record is a repo plus the derived values shown in the TUI. Derived names
+// must not collide with repo.Repo's, or they hide its fields.
+type Record struct {
+	repo.Repo
+	DaysSinceUpdate   int    `json:"daysSinceUpdate"`
+	DaysSinceActivity int    `json:"daysSinceActivity"`
+	ArchiveScore      int    `json:"archiveScore"`
+	Size              string `json:"size"`
+	VisibilityLabel   string `json:"visibilityLabel"`
+	Status            string `json:"status"`
+}
+
+// NewRecord builds a Record from a repo
+func NewRecord(r repo.Repo) Record {
+	return Record{
+		Repo:              r,
+		DaysSinceUpdate:   r.DaysSinceUpdate(),
+		DaysSinceActivity: r.DaysSinceActivity(repo.ActivityAny),
+		ArchiveScore:      r.ArchiveScore().Total,
+		Size:              r.SizeString(),
+		VisibilityLabel:   r.VisibilityString(),
+		Status:            r.StatusString(),
+	}
+}
+
+// Records converts a list of repos
+func Records(repos []repo.Repo) []Record {
+	records := make([]Record, len(repos))
+	for i, r := range repos {
+		records[i] = NewRecord(r)
+	}
+	return records
+}
+
+// Write renders repos in the given format. A non-empty tmpl takes precedence.
+func Write(w io.Writer, repos []repo.Repo, format Format, tmpl string) error {
+	if tmpl != "" {
+		return writeTemplate(w, repos, tmpl)
+	}
+
+	switch format {
+	case FormatTable, "":
+		return writeTable(w, repos)
+	case FormatJSON:
+		return writeJSON(w, repos)
+	case FormatNDJSON:
+		return writeNDJSON(w, repos)
+	case FormatCSV:
+		return writeCSV(w, repos)
+	case FormatTSV:
+		return writeTSV(w, repos)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// writeTable prints repos as an aligned plain-text table
+func writeTable(w io.Writer, repos []repo.Repo) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVISIBILITY\tSTATUS\tLANGUAGE\tSTARS\tFORKS\tINACTIVE\tSIZE")
+	for _, r := range repos {
+		lang := r.PrimaryLanguage
+		if lang == "" {
+			lang = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%dd\t%s\n",
+			r.FullName, r.VisibilityString(), r.StatusString(), lang,
+			r.StargazerCount, r.ForkCount, r.DaysSinceUpdate(), r.SizeString())
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, repos []repo.Repo) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Records(repos))
+}
+
+func writeNDJSON(w io.Writer, repos []repo.Repo) error {
+	enc := json.NewEncoder(w)
+	for _, r := range repos {
+		if err := enc.Encode(NewRecord(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// column is a single CSV/TSV column
+type column struct {
+	header string
+	value  func(r repo.Repo) string
+}
+
+var columns = []column{
+	{"name", func(r repo.Repo) string { return r.Name }},
+	{"full_name", func(r repo.Repo) string { return r.FullName }},
+	{"description", func(r repo.Repo) string { return r.Description }},
+	{"url", func(r repo.Repo) string { return r.URL }},
+	{"visibility", func(r repo.Repo) string { return r.VisibilityString() }},
+	{"archived", func(r repo.Repo) string { return strconv.FormatBool(r.IsArchived) }},
+	{"fork", func(r repo.Repo) string { return strconv.FormatBool(r.IsFork) }},
+	{"template", func(r repo.Repo) string { return strconv.FormatBool(r.IsTemplate) }},
+	{"mirror", func(r repo.Repo) string { return strconv.FormatBool(r.IsMirror) }},
+	{"disabled", func(r repo.Repo) string { return strconv.FormatBool(r.IsDisabled) }},
+	{"locked", func(r repo.Repo) string { return strconv.FormatBool(r.IsLocked) }},
+	{"language", func(r repo.Repo) string { return r.PrimaryLanguage }},
+	{"license", func(r repo.Repo) string { return r.License }},
+	{"default_branch", func(r repo.Repo) string { return r.DefaultBranch }},
+	{"homepage", func(r repo.Repo) string { return r.HomepageURL }},
+	{"topics", func(r repo.Repo) string { return strings.Join(r.Topics, ",") }},
+	{"stars", func(r repo.Repo) string { return strconv.Itoa(r.StargazerCount) }},
+	{"forks", func(r repo.Repo) string { return strconv.Itoa(r.ForkCount) }},
+	{"open_issues", func(r repo.Repo) string { return strconv.Itoa(r.OpenIssuesCount) }},
+	{"created_at", func(r repo.Repo) string { return formatTime(r.CreatedAt) }},
+	{"updated_at", func(r repo.Repo) string { return formatTime(r.UpdatedAt) }},
+	{"pushed_at", func(r repo.Repo) string { return formatTime(r.PushedAt) }},
+	{"days_since_update", func(r repo.Repo) string { return strconv.Itoa(r.DaysSinceUpdate()) }},
+	{"last_commit_at", func(r repo.Repo) string { return formatTime(r.LastCommitAt) }},
+	{"last_issue_at", func(r repo.Repo) string { return formatTime(r.LastIssueAt) }},
+	{"last_pull_request_at", func(r repo.Repo) string { return formatTime(r.LastPRAt) }},
+	{"last_release_at", func(r repo.Repo) string { return formatTime(r.LastReleaseAt) }},
+	{"days_since_activity", func(r repo.Repo) string { return strconv.Itoa(r.DaysSinceActivity(repo.ActivityAny)) }},
+	{"archive_score", func(r repo.Repo) string { return strconv.Itoa(r.ArchiveScore().Total) }},
+	{"open_pull_requests", func(r repo.Repo) string { return strconv.Itoa(r.OpenPRCount) }},
+	{"contributors", func(r repo.Repo) string { return contributors(r) }},
+	{"disk_usage_kb", func(r repo.Repo) string { return strconv.Itoa(r.DiskUsage) }},
+	{"size", func(r repo.Repo) string { return r.SizeString() }},
+}
+
+// row returns the column values for a repo
+func row(r repo.Repo) []string {
+	values := make([]string, len(columns))
+	for i, c := range columns {
+		values[i] = c.value(r)
+	}
+	return values
+}
+
+func header() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.header
+	}
+	return names
+}
+
+func writeCSV(w io.Writer, repos []repo.Repo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header()); err != nil {
+		return err
+	}
+	for _, r := range repos {
+		if err := cw.Write(row(r)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// tsvEscaper keeps each record on one line since TSV has no quoting
+var tsvEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+func writeTSV(w io.Writer, repos []repo.Repo) error {
+	if _, err := fmt.Fprintln(w, strings.Join(header(), "\t")); err != nil {
+		return err
+	}
+	for _, r := range repos {
+		values := row(r)
+		for i := range values {
+			values[i] = tsvEscaper.Replace(values[i])
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateFuncs are available in --template, similar to gh's helpers
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"truncate": func(max int, s string) string {
+		// Counted in runes so multi-byte characters aren't cut in half
+		runes := []rune(s)
+		if len(runes) <= max {
+			return s
+		}
+		if max <= 3 {
+			return string(runes[:max])
+		}
+		return string(runes[:max-3]) + "..."
+	},
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+}
+
+// writeTemplate executes tmpl with the list of records as its data
+func writeTemplate(w io.Writer, repos []repo.Repo, tmpl string) error {
+	t, err := template.New("output").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return t.Execute(w, Records(repos))
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// contributors returns the contributor count, empty when it wasn't fetched
+func contributors(r repo.Repo) string {
+	if r.Contributors < 0 {
+		return ""
+	}
+	return strconv.Itoa(r.Contributors)
+}
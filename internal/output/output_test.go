@@ -0,0 +1,135 @@
+// ABOUTME: Tests for the listing renderers: JSON keys, CSV/TSV rows, templates and format parsing.
+// ABOUTME: Guards that derived fields don't hide repo fields in machine-readable output.
+
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func sample() repo.Repo {
+	pushed := time.Now().AddDate(0, 0, -30)
+	return repo.Repo{
+		Name:        "tool",
+		FullName:    "acme/tool",
+		Description: "Tabs\tand\nnewlines",
+		Visibility:  "INTERNAL",
+		CreatedAt:   pushed,
+		PushedAt:    pushed,
+		DiskUsage:   2048,
+		Topics:      []string{"go", "cli"},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"json", FormatJSON, false},
+		{"NDJSON", FormatNDJSON, false},
+		{"tsv", FormatTSV, false},
+		{"yaml", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v", tt.name, got, err)
+		}
+	}
+}
+
+func TestJSONKeepsRawVisibility(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatNDJSON} {
+		var buf bytes.Buffer
+		if err := Write(&buf, []repo.Repo{sample()}, format, ""); err != nil {
+			t.Fatal(err)
+		}
+		data := bytes.TrimSpace(buf.Bytes())
+		var record map[string]interface{}
+		if format == FormatJSON {
+			var records []map[string]interface{}
+			if err := json.Unmarshal(data, &records); err != nil {
+				t.Fatal(err)
+			}
+			record = records[0]
+		} else if err := json.Unmarshal(data, &record); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[string]interface{}{
+			"visibility":      "INTERNAL",
+			"visibilityLabel": "Internal",
+			"size":            "2.0 MB",
+			"daysSinceUpdate": 30.0,
+		}
+		for key, value := range want {
+			if record[key] != value {
+				t.Errorf("%s: %s = %v, want %v", format, key, record[key], value)
+			}
+		}
+	}
+}
+
+func TestDelimited(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []repo.Repo{sample()}, FormatCSV, ""); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(rows[0]) != len(rows[1]) {
+		t.Fatalf("got %d rows of uneven width", len(rows))
+	}
+	values := make(map[string]string)
+	for i, h := range rows[0] {
+		values[h] = rows[1][i]
+	}
+	if values["visibility"] != "Internal" || values["topics"] != "go,cli" || values["description"] != "Tabs\tand\nnewlines" {
+		t.Errorf("CSV row %v", values)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, []repo.Repo{sample()}, FormatTSV, ""); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("TSV record spans %d lines, want 1", len(lines)-1)
+	}
+	if !strings.Contains(lines[1], "Tabs and newlines") {
+		t.Errorf("TSV row %q", lines[1])
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{range .}}{{.FullName}} {{.Visibility}} {{.VisibilityLabel}}{{end}}`, "acme/tool INTERNAL Internal"},
+		{`{{range .}}{{join .Topics "+"}}{{end}}`, "go+cli"},
+		{`{{truncate 5 "héllo wörld"}}`, "hé..."},
+		{`{{truncate 4 "ääää"}}`, "ääää"},
+		{`{{truncate 2 "日本語"}}`, "日本"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, []repo.Repo{sample()}, FormatTable, tt.tmpl); err != nil {
+			t.Fatalf("%s: %v", tt.tmpl, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
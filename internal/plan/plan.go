@@ -0,0 +1,119 @@
+// ABOUTME: Builds dry-run plans describing which repos an action would change and why.
+// ABOUTME: Plans render as plain text for sign-off or JSON for tooling.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/cache"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// Item is a single repo in a plan
+type Item struct {
+	Repo    string   `json:"repo"`
+	Reasons []string `json:"reasons"`
+}
+
+// Plan lists what an action would do without doing it
+type Plan struct {
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"createdAt"`
+	Filters   []string  `json:"filters"`
+	Items     []Item    `json:"items"`
+}
+
+// New builds a plan for action over repos. Repos that don't pass the
+// filter options are reported as selected manually.
+func New(action string, repos []repo.Repo, opts repo.FilterOptions) Plan {
+	p := Plan{
+		Action:    action,
+		CreatedAt: time.Now(),
+		Filters:   repo.DescribeFilter(opts),
+	}
+
+	for _, r := range repos {
+		var reasons []string
+		if len(repo.Filter([]repo.Repo{r}, opts)) == 1 {
+			reasons = repo.MatchReasons(r, opts)
+		} else {
+			reasons = []string{"selected manually (outside current filters)"}
+		}
+		p.Items = append(p.Items, Item{Repo: r.FullName, Reasons: reasons})
+	}
+	return p
+}
+
+// Named builds a plan for repos given explicitly by name
+func Named(action string, names []string) Plan {
+	p := Plan{Action: action, CreatedAt: time.Now()}
+	for _, name := range names {
+		p.Items = append(p.Items, Item{Repo: name, Reasons: []string{"named explicitly"}})
+	}
+	return p
+}
+
+// WriteText renders the plan for humans
+func (p Plan) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dry run: %s %d %s\n", p.Action, len(p.Items), pluralize(len(p.Items), "repository", "repositories"))
+	fmt.Fprintf(&b, "Generated: %s\n", p.CreatedAt.Format(time.RFC1123))
+	if len(p.Filters) > 0 {
+		fmt.Fprintf(&b, "Filters: %s\n", strings.Join(p.Filters, ", "))
+	}
+	b.WriteString("\n")
+	for _, item := range p.Items {
+		fmt.Fprintf(&b, "  %s\n", item.Repo)
+		for _, reason := range item.Reasons {
+			fmt.Fprintf(&b, "      - %s\n", reason)
+		}
+	}
+	b.WriteString("\nNo changes were made.\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON renders the plan for tooling
+func (p Plan) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// Save writes the text plan into the cache directory and returns its path
+func (p Plan) Save() (string, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "plans")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.txt", p.Action, p.CreatedAt.Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := p.WriteText(f); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,126 @@
+// ABOUTME: Tests for dry-run plans: per-repo reasons, text and JSON rendering and saving.
+// ABOUTME: Saved plans go to a temporary HOME so the real cache directory is untouched.
+
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func TestNew(t *testing.T) {
+	opts := repo.DefaultFilterOptions()
+	opts.Language = "Go"
+	opts.ShowForks = false
+	repos := []repo.Repo{
+		{FullName: "acme/tool", PrimaryLanguage: "Go"},
+		{FullName: "acme/site", PrimaryLanguage: "Ruby"},
+		{FullName: "acme/fork", PrimaryLanguage: "Go", IsFork: true},
+	}
+
+	p := New("archive", repos, opts)
+	if p.Action != "archive" || !reflect.DeepEqual(p.Filters, repo.DescribeFilter(opts)) {
+		t.Errorf("plan header %q %v", p.Action, p.Filters)
+	}
+
+	manual := []string{"selected manually (outside current filters)"}
+	tests := []struct {
+		repo    string
+		reasons []string
+	}{
+		{"acme/tool", []string{"language is Go", "not a fork"}},
+		{"acme/site", manual},
+		{"acme/fork", manual},
+	}
+	if len(p.Items) != len(tests) {
+		t.Fatalf("got %d items, want %d", len(p.Items), len(tests))
+	}
+	for i, tt := range tests {
+		item := p.Items[i]
+		if item.Repo != tt.repo || !reflect.DeepEqual(item.Reasons, tt.reasons) {
+			t.Errorf("item %d = %s %q, want %s %q", i, item.Repo, item.Reasons, tt.repo, tt.reasons)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		plan Plan
+		want []string
+	}{
+		{
+			"single named repo",
+			Plan{Action: "delete", CreatedAt: created, Items: []Item{{Repo: "acme/old", Reasons: []string{"named explicitly"}}}},
+			[]string{"Dry run: delete 1 repository\n", "  acme/old\n      - named explicitly\n", "No changes were made."},
+		},
+		{
+			"filtered repos",
+			Plan{Action: "archive", CreatedAt: created, Filters: []string{"excluding forks", "language Go"}, Items: []Item{
+				{Repo: "acme/a", Reasons: []string{"language is Go"}},
+				{Repo: "acme/b", Reasons: []string{"language is Go"}},
+			}},
+			[]string{"Dry run: archive 2 repositories\n", "Filters: excluding forks, language Go\n", "  acme/b\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var text bytes.Buffer
+			if err := tt.plan.WriteText(&text); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text.String(), want) {
+					t.Errorf("text plan lacks %q:\n%s", want, text.String())
+				}
+			}
+
+			var data bytes.Buffer
+			if err := tt.plan.WriteJSON(&data); err != nil {
+				t.Fatal(err)
+			}
+			var decoded Plan
+			if err := json.Unmarshal(data.Bytes(), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.CreatedAt.Equal(tt.plan.CreatedAt) {
+				t.Errorf("createdAt = %v", decoded.CreatedAt)
+			}
+			decoded.CreatedAt = tt.plan.CreatedAt
+			if !reflect.DeepEqual(decoded, tt.plan) {
+				t.Errorf("JSON round trip = %+v, want %+v", decoded, tt.plan)
+			}
+		})
+	}
+}
+
+func TestNamedAndSave(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	p := Named("unarchive", []string{"acme/a", "acme/b"})
+	path, err := p.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDir := filepath.Join(home, ".cache", "gh-repo-review", "plans")
+	if filepath.Dir(path) != wantDir || !strings.HasPrefix(filepath.Base(path), "unarchive-") {
+		t.Errorf("saved to %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "unarchive 2 repositories") || strings.Contains(string(data), "Filters:") {
+		t.Errorf("saved plan:\n%s", data)
+	}
+}
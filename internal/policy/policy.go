@@ -0,0 +1,284 @@
+// ABOUTME: Declarative retention policy loaded from YAML and evaluated against repositories.
+// ABOUTME: Rules reuse repo.Filter semantics so a policy matches what the same filters show in the TUI.
+
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/config"
+	"github.com/user/gh-repo-review/internal/plan"
+	"github.com/user/gh-repo-review/internal/query"
+	"github.com/user/gh-repo-review/internal/repo"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions a rule may ask for
+const (
+	ActionArchive = "archive"
+	ActionDelete  = "delete"
+)
+
+// Policy is a set of rules plus repositories no rule may touch
+type Policy struct {
+	Rules   []Rule    `yaml:"rules"`
+	Exclude Exclusion `yaml:"exclude"`
+
+	// Path is the file the policy was loaded from
+	Path string `yaml:"-"`
+}
+
+// Rule applies Action to every repository matching Match
+type Rule struct {
+	Name   string `yaml:"name"`
+	Action string `yaml:"action"`
+	Match  Match  `yaml:"match"`
+}
+
+// Match mirrors repo.FilterOptions. Unset fields don't narrow the match,
+// except that archived repositories are skipped unless archived is true.
+type Match struct {
+	Visibility    string   `yaml:"visibility"` // all, public, private or internal
+	Archived      *bool    `yaml:"archived"`   // true: only archived, false: only unarchived
+	Fork          *bool    `yaml:"fork"`       // true: only forks, false: no forks
+	Language      string   `yaml:"language"`
+	License       string   `yaml:"license"` // SPDX id, or none for unlicensed
+	MinStars      *int     `yaml:"min_stars"`
+	MaxStars      *int     `yaml:"max_stars"`
+	InactiveDays  int      `yaml:"inactive_days"`
+	InactiveBy    string   `yaml:"inactive_by"` // any (default), push, commit, issues, prs or release
+	Search        string   `yaml:"search"`
+	Topics        []string `yaml:"topics"`
+	ExcludeTopics []string `yaml:"exclude_topics"`
+	Query         string   `yaml:"query"` // query language, e.g. "lang:go -topic:keep"
+}
+
+// Exclusion protects repositories from every rule
+type Exclusion struct {
+	Topics []string `yaml:"topics"`
+	// Repos are owner/name patterns; * and ? match within a path segment
+	Repos []string `yaml:"repos"`
+}
+
+// Violation is a repository that a rule says should be changed
+type Violation struct {
+	Repo    repo.Repo
+	Rule    string
+	Action  string
+	Reasons []string
+}
+
+// DefaultPath returns $XDG_CONFIG_HOME/gh-repo-review/policy.yml,
+// falling back to ~/.config/gh-repo-review/policy.yml
+func DefaultPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "policy.yml"), nil
+}
+
+// Load reads and validates a policy file. Unknown keys are errors so typos
+// don't silently widen a rule.
+func Load(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	p.Path = filename
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return &p, nil
+}
+
+// LoadDefault loads the policy at DefaultPath, returning nil if there is none
+func LoadDefault() (*Policy, error) {
+	filename, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return Load(filename)
+}
+
+// Validate checks every rule for a name, a known action and valid filters
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("policy has no rules")
+	}
+	seen := make(map[string]bool)
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: missing name", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("rule %q: duplicate name", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		switch rule.Action {
+		case ActionArchive, ActionDelete:
+		default:
+			return fmt.Errorf("rule %q: unknown action %q (want archive or delete)", rule.Name, rule.Action)
+		}
+		if _, err := rule.Match.Options(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	for _, pattern := range p.Exclude.Repos {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exclude: bad repo pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Options converts the match into filter options
+func (m Match) Options() (repo.FilterOptions, error) {
+	opts := repo.DefaultFilterOptions()
+
+	switch strings.ToLower(m.Visibility) {
+	case "all", "":
+	case "public":
+		opts.ShowPrivate = false
+	case "private":
+		opts.ShowPublic = false
+	case "internal":
+		opts.ShowPublic = false
+		opts.OnlyInternal = true
+	default:
+		return opts, fmt.Errorf("invalid visibility %q (want all, public, private or internal)", m.Visibility)
+	}
+
+	if m.Archived != nil && *m.Archived {
+		opts.ShowArchived = true
+		opts.OnlyArchived = true
+	}
+	if m.Fork != nil {
+		opts.ShowForks = *m.Fork
+		opts.OnlyForks = *m.Fork
+	}
+	if m.MinStars != nil {
+		opts.MinStars = *m.MinStars
+	}
+	if m.MaxStars != nil {
+		opts.MaxStars = *m.MaxStars
+	}
+	if m.InactiveDays < 0 {
+		return opts, fmt.Errorf("inactive_days must not be negative")
+	}
+	opts.Language = m.Language
+	opts.License = m.License
+	opts.InactiveForDays = m.InactiveDays
+	if m.InactiveBy != "" {
+		signal, err := repo.ParseActivitySignal(m.InactiveBy)
+		if err != nil {
+			return opts, err
+		}
+		opts.InactiveBy = signal
+	}
+	opts.SearchQuery = m.Search
+	opts.Topics = m.Topics
+	opts.ExcludeTopics = m.ExcludeTopics
+	if m.Query != "" {
+		q, err := query.Parse(m.Query)
+		if err != nil {
+			return opts, err
+		}
+		opts.Query = q
+	}
+	return opts, nil
+}
+
+// Excluded reports whether r is protected by the exclude section
+func (p *Policy) Excluded(r repo.Repo) bool {
+	if r.HasAnyTopic(p.Exclude.Topics) {
+		return true
+	}
+	for _, pattern := range p.Exclude.Repos {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(r.FullName)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate returns the violations in repos. Each repository is reported for
+// the first rule it matches; archive rules skip repos that are already archived.
+func (p *Policy) Evaluate(repos []repo.Repo) []Violation {
+	var violations []Violation
+	for _, r := range repos {
+		if v, ok := p.Check(r); ok {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// Check returns the violation for a single repository, if any
+func (p *Policy) Check(r repo.Repo) (Violation, bool) {
+	if p.Excluded(r) {
+		return Violation{}, false
+	}
+	for _, rule := range p.Rules {
+		if rule.Action == ActionArchive && r.IsArchived {
+			continue
+		}
+		opts, err := rule.Match.Options()
+		if err != nil {
+			continue
+		}
+		if len(repo.Filter([]repo.Repo{r}, opts)) == 0 {
+			continue
+		}
+		return Violation{
+			Repo:    r,
+			Rule:    rule.Name,
+			Action:  rule.Action,
+			Reasons: repo.MatchReasons(r, opts),
+		}, true
+	}
+	return Violation{}, false
+}
+
+// Plans groups violations into one plan per action, archive first
+func (p *Policy) Plans(violations []Violation) []plan.Plan {
+	var plans []plan.Plan
+	for _, action := range []string{ActionArchive, ActionDelete} {
+		pl := plan.Plan{
+			Action:    action,
+			CreatedAt: time.Now(),
+			Filters:   []string{"policy " + p.Path},
+		}
+		for _, v := range violations {
+			if v.Action != action {
+				continue
+			}
+			reasons := append([]string{"rule " + v.Rule}, v.Reasons...)
+			pl.Items = append(pl.Items, plan.Item{Repo: v.Repo.FullName, Reasons: reasons})
+		}
+		if len(pl.Items) > 0 {
+			plans = append(plans, pl)
+		}
+	}
+	return plans
+}
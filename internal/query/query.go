@@ -0,0 +1,518 @@
+// ABOUTME: Small query language for repositories, e.g. `lang:go stars:<5 -is:fork topic:cli`.
+// ABOUTME: Queries parse into an AST of and/or/not nodes over qualifier terms and free text.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// Node is an element of the query AST
+type Node interface {
+	Match(r repo.Repo) bool
+	String() string
+}
+
+// Query is a parsed query. It implements repo.Matcher.
+type Query struct {
+	Root Node
+	Text string
+}
+
+// Match reports whether r satisfies the query. An empty query matches everything.
+func (q *Query) Match(r repo.Repo) bool {
+	return q.Root == nil || q.Root.Match(r)
+}
+
+func (q *Query) String() string {
+	return q.Text
+}
+
+// Error is a parse error at a byte offset in the query
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("query column %d: %s", e.Pos+1, e.Msg)
+}
+
+// Parse parses a query. Terms are joined with AND unless separated by OR;
+// parentheses group, and a leading - or NOT negates.
+func Parse(text string) (*Query, error) {
+	toks, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	q := &Query{Text: strings.TrimSpace(text)}
+	if len(toks) == 0 {
+		return q, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	q.Root = root
+	return q, nil
+}
+
+// Help summarizes the syntax for usage text and the TUI
+const Help = `Free text matches name and description. Qualifiers:
+  lang:go  topic:cli  owner:acme  name:api  license:mit  license:none  branch:master
+  is:fork is:archived is:private is:public is:internal is:template
+  is:mirror is:disabled is:locked
+  stars:<5 forks:>=10 issues:0 prs:>0 contributors:1 size:>100MB inactive:>365 score:>=70
+  pushed:<2023-01-01 created:2020-01-01..2021-01-01 updated:>=2024-06-01
+  committed:<2022-01-01 released:>2024-01-01 activity:<2023-01-01
+Combine with spaces (AND), OR and parentheses; negate with - or NOT.`
+
+// token is a lexical token
+type token struct {
+	pos  int
+	text string
+	kind tokenKind
+	// literal words start with a quote and are always free text
+	literal bool
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokLParen
+	tokRParen
+)
+
+// lex splits text into words and parentheses. Double quotes keep spaces
+// inside a word and are removed.
+func lex(text string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(text) {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			toks = append(toks, token{pos: i, text: "(", kind: tokLParen})
+			i++
+		case c == ')':
+			toks = append(toks, token{pos: i, text: ")", kind: tokRParen})
+			i++
+		default:
+			start := i
+			var b strings.Builder
+			inQuote := false
+			for i < len(text) {
+				c := text[i]
+				if c == '"' {
+					inQuote = !inQuote
+					i++
+					continue
+				}
+				if !inQuote && (c == ' ' || c == '\t' || c == '(' || c == ')') {
+					break
+				}
+				b.WriteByte(c)
+				i++
+			}
+			if inQuote {
+				return nil, &Error{Pos: start, Msg: "unterminated quote"}
+			}
+			toks = append(toks, token{pos: start, text: b.String(), kind: tokWord, literal: text[start] == '"'})
+		}
+	}
+	return toks, nil
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() *token {
+	if p.i < len(p.toks) {
+		return &p.toks[p.i]
+	}
+	return nil
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokWord && t.text == word
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []Node{left}
+	for p.isKeyword("OR") {
+		p.i++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, right)
+	}
+	if len(nodes) == 1 {
+		return left, nil
+	}
+	return orNode(nodes), nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for {
+		t := p.peek()
+		if t == nil || t.kind == tokRParen || p.isKeyword("OR") {
+			break
+		}
+		if p.isKeyword("AND") {
+			p.i++
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 0 {
+		return nil, p.expectedTerm()
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return andNode(nodes), nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	t := p.peek()
+	if t == nil || t.kind == tokRParen {
+		return nil, p.expectedTerm()
+	}
+	if p.isKeyword("NOT") {
+		p.i++
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+
+	if t.kind == tokLParen {
+		p.i++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.peek(); c == nil || c.kind != tokRParen {
+			return nil, &Error{Pos: t.pos, Msg: "missing closing parenthesis"}
+		}
+		p.i++
+		return n, nil
+	}
+
+	p.i++
+	word := t.text
+	if t.literal {
+		return textTerm(word), nil
+	}
+	if strings.HasPrefix(word, "-") && len(word) > 1 {
+		n, err := parseTerm(word[1:], t.pos+1)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	return parseTerm(word, t.pos)
+}
+
+// expectedTerm reports a missing term at the current token or the end of input
+func (p *parser) expectedTerm() error {
+	pos := 0
+	if t := p.peek(); t != nil {
+		pos = t.pos
+	} else if len(p.toks) > 0 {
+		last := p.toks[len(p.toks)-1]
+		pos = last.pos + len(last.text)
+	}
+	return &Error{Pos: pos, Msg: "expected a term"}
+}
+
+type andNode []Node
+
+func (n andNode) Match(r repo.Repo) bool {
+	for _, c := range n {
+		if !c.Match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n andNode) String() string {
+	return joinNodes(n, " ")
+}
+
+type orNode []Node
+
+func (n orNode) Match(r repo.Repo) bool {
+	for _, c := range n {
+		if c.Match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n orNode) String() string {
+	return "(" + joinNodes(n, " OR ") + ")"
+}
+
+type notNode struct{ Node }
+
+func (n notNode) Match(r repo.Repo) bool {
+	return !n.Node.Match(r)
+}
+
+func (n notNode) String() string {
+	return "-" + n.Node.String()
+}
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = n.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// term is a leaf: free text or a qualifier
+type term struct {
+	text  string
+	match func(r repo.Repo) bool
+}
+
+func (t term) Match(r repo.Repo) bool {
+	return t.match(r)
+}
+
+func (t term) String() string {
+	return t.text
+}
+
+// stringQualifiers compare a repo field with the value
+var stringQualifiers = map[string]func(r repo.Repo, v string) bool{
+	"lang":     func(r repo.Repo, v string) bool { return strings.EqualFold(r.PrimaryLanguage, v) },
+	"language": func(r repo.Repo, v string) bool { return strings.EqualFold(r.PrimaryLanguage, v) },
+	"topic":    func(r repo.Repo, v string) bool { return r.HasAnyTopic([]string{v}) },
+	"owner":    func(r repo.Repo, v string) bool { return strings.EqualFold(r.OwnerLogin(), v) },
+	"name":     func(r repo.Repo, v string) bool { return containsFold(r.Name, v) },
+	"license":  func(r repo.Repo, v string) bool { return r.HasLicense(v) },
+	"branch":   func(r repo.Repo, v string) bool { return strings.EqualFold(r.DefaultBranch, v) },
+}
+
+// isValues are the accepted is: qualifiers
+var isValues = map[string]func(r repo.Repo) bool{
+	"fork":     func(r repo.Repo) bool { return r.IsFork },
+	"archived": func(r repo.Repo) bool { return r.IsArchived },
+	"private":  func(r repo.Repo) bool { return r.VisibilityString() == "Private" },
+	"public":   func(r repo.Repo) bool { return r.VisibilityString() == "Public" },
+	"internal": func(r repo.Repo) bool { return r.IsInternal() },
+	"template": func(r repo.Repo) bool { return r.IsTemplate },
+	"mirror":   func(r repo.Repo) bool { return r.IsMirror },
+	"disabled": func(r repo.Repo) bool { return r.IsDisabled },
+	"locked":   func(r repo.Repo) bool { return r.IsLocked },
+}
+
+// numberQualifiers return the repo value compared against numbers. A
+// negative value, such as a contributor count that wasn't fetched, is
+// unknown and matches no comparison.
+var numberQualifiers = map[string]func(r repo.Repo) int64{
+	"stars":        func(r repo.Repo) int64 { return int64(r.StargazerCount) },
+	"forks":        func(r repo.Repo) int64 { return int64(r.ForkCount) },
+	"issues":       func(r repo.Repo) int64 { return int64(r.OpenIssuesCount) },
+	"size":         func(r repo.Repo) int64 { return int64(r.DiskUsage) },
+	"inactive":     func(r repo.Repo) int64 { return int64(r.DaysSinceActivity(repo.ActivityAny)) },
+	"prs":          func(r repo.Repo) int64 { return int64(r.OpenPRCount) },
+	"contributors": func(r repo.Repo) int64 { return int64(r.Contributors) },
+	"score":        func(r repo.Repo) int64 { return int64(r.ArchiveScore().Total) },
+}
+
+// dateQualifiers return the repo time compared against dates
+var dateQualifiers = map[string]func(r repo.Repo) time.Time{
+	"pushed":    func(r repo.Repo) time.Time { return r.PushedAt },
+	"created":   func(r repo.Repo) time.Time { return r.CreatedAt },
+	"updated":   func(r repo.Repo) time.Time { return r.UpdatedAt },
+	"committed": func(r repo.Repo) time.Time { return r.LastCommitAt },
+	"released":  func(r repo.Repo) time.Time { return r.LastReleaseAt },
+	"activity":  func(r repo.Repo) time.Time { return r.LastActivity(repo.ActivityAny) },
+}
+
+// parseTerm parses free text or a key:value qualifier starting at pos
+func parseTerm(word string, pos int) (Node, error) {
+	key, value, ok := strings.Cut(word, ":")
+	if !ok {
+		return textTerm(word), nil
+	}
+
+	key = strings.ToLower(key)
+	valuePos := pos + len(key) + 1
+	if value == "" {
+		return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("missing value for %s:", key)}
+	}
+
+	if f, ok := stringQualifiers[key]; ok {
+		return term{text: word, match: func(r repo.Repo) bool { return f(r, value) }}, nil
+	}
+
+	if key == "is" {
+		f, ok := isValues[strings.ToLower(value)]
+		if !ok {
+			return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("unknown is:%s (want fork, archived, private, public, internal, template, mirror, disabled or locked)", value)}
+		}
+		return term{text: word, match: f}, nil
+	}
+
+	if f, ok := numberQualifiers[key]; ok {
+		parse := parseNumber
+		if key == "size" {
+			parse = parseSize
+		}
+		cmp, err := parseComparison(value, parse)
+		if err != nil {
+			return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("%s: %v", key, err)}
+		}
+		return term{text: word, match: func(r repo.Repo) bool {
+			v := f(r)
+			return v >= 0 && cmp(v)
+		}}, nil
+	}
+
+	if f, ok := dateQualifiers[key]; ok {
+		cmp, err := parseComparison(value, parseDate)
+		if err != nil {
+			return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("%s: %v", key, err)}
+		}
+		return term{text: word, match: func(r repo.Repo) bool { return cmp(dayNumber(f(r))) }}, nil
+	}
+
+	return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unknown qualifier %q", key)}
+}
+
+// textTerm matches text in the name or description
+func textTerm(text string) Node {
+	return term{text: text, match: func(r repo.Repo) bool {
+		return containsFold(r.Name, text) || containsFold(r.Description, text)
+	}}
+}
+
+// parseComparison parses ">N", ">=N", "<N", "<=N", "N", "N..M" (either end may be *)
+func parseComparison(value string, parse func(string) (int64, error)) (func(int64) bool, error) {
+	if lo, hi, ok := strings.Cut(value, ".."); ok {
+		var min, max int64
+		var err error
+		hasMin, hasMax := lo != "*", hi != "*"
+		if hasMin {
+			if min, err = parse(lo); err != nil {
+				return nil, err
+			}
+		}
+		if hasMax {
+			if max, err = parse(hi); err != nil {
+				return nil, err
+			}
+		}
+		return func(v int64) bool {
+			return (!hasMin || v >= min) && (!hasMax || v <= max)
+		}, nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if !strings.HasPrefix(value, op) {
+			continue
+		}
+		n, err := parse(value[len(op):])
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case ">=":
+			return func(v int64) bool { return v >= n }, nil
+		case "<=":
+			return func(v int64) bool { return v <= n }, nil
+		case ">":
+			return func(v int64) bool { return v > n }, nil
+		case "<":
+			return func(v int64) bool { return v < n }, nil
+		}
+		return func(v int64) bool { return v == n }, nil
+	}
+
+	n, err := parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(v int64) bool { return v == n }, nil
+}
+
+func parseNumber(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// parseSize parses a size in KB, or with a KB, MB or GB suffix
+func parseSize(s string) (int64, error) {
+	upper := strings.ToUpper(s)
+	mult := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1024 * 1024}, {"MB", 1024}, {"KB", 1}} {
+		if strings.HasSuffix(upper, unit.suffix) {
+			upper = strings.TrimSuffix(upper, unit.suffix)
+			mult = unit.mult
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(upper, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q (e.g. 500, 100MB, 2GB)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// parseDate parses YYYY-MM-DD into a day number
+func parseDate(s string) (int64, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q (want YYYY-MM-DD)", s)
+	}
+	return dayNumber(t), nil
+}
+
+// dayNumber counts whole UTC days since the Unix epoch so dates compare by day
+func dayNumber(t time.Time) int64 {
+	return t.UTC().Unix() / 86400
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,137 @@
+// ABOUTME: Tests for the query language: operator precedence, quoting and error positions.
+// ABOUTME: Parsed queries are checked through their String form and by matching sample repos.
+
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"a b OR c", "(a b OR c)"},
+		{"a OR b c", "(a OR b c)"},
+		{"a (b OR c)", "a (b OR c)"},
+		{"a AND b OR c AND d", "(a b OR c d)"},
+		{"NOT a b", "-a b"},
+		{"-a OR b", "(-a OR b)"},
+		{"NOT (a OR b)", "-(a OR b)"},
+		{"((a))", "a"},
+		{"a OR b OR c", "(a OR b OR c)"},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if got := q.Root.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	old := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	cli := repo.Repo{
+		Name:            "my tool",
+		FullName:        "acme/my tool",
+		Description:     "A command line tool",
+		PrimaryLanguage: "Go",
+		StargazerCount:  3,
+		IsFork:          true,
+		Visibility:      "PUBLIC",
+		Topics:          []string{"cli"},
+		PushedAt:        old,
+		DiskUsage:       2048,
+		Contributors:    -1,
+	}
+
+	counted := cli
+	counted.Contributors = 1
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"lang:go stars:<5", true},
+		{"lang:go -is:fork", false},
+		{"lang:rust OR topic:cli", true},
+		{"lang:rust OR topic:web", false},
+		{"lang:rust OR topic:web is:fork", false},
+		{"(lang:rust OR topic:cli) is:fork", true},
+		{"NOT lang:go OR is:public", true},
+		{`"my tool"`, true},
+		{`name:"my tool"`, true},
+		{`"lang:go"`, false},
+		{"COMMAND", true},
+		{"stars:1..3 size:>1MB", true},
+		{"stars:4..* ", false},
+		{"pushed:<2021-01-01 pushed:>=2020-03-01", true},
+		{"pushed:2020-03-02", false},
+		{"contributors:0", false},
+		{"contributors:<2", false},
+		{"-contributors:>5", true},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if got := q.Match(cli); got != tt.want {
+			t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"contributors:1", "contributors:<2", "contributors:0..1"} {
+		q, err := Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q.Match(counted) {
+			t.Errorf("%q didn't match a repo with 1 contributor", text)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{`a "b c`, 2, "unterminated quote"},
+		{"(a b", 0, "missing closing parenthesis"},
+		{"a (b OR c", 2, "missing closing parenthesis"},
+		{"a OR", 4, "expected a term"},
+		{"OR a", 0, "expected a term"},
+		{"()", 1, "expected a term"},
+		{"a )", 2, `unexpected ")"`},
+		{"lang:", 5, "missing value for lang:"},
+		{"a foo:bar", 2, `unknown qualifier "foo"`},
+		{"is:forked", 3, "unknown is:forked (want fork, archived, private, public, internal, template, mirror, disabled or locked)"},
+		{"-stars:abc", 7, `stars: invalid number "abc"`},
+		{"a SIZE:big", 7, `size: invalid size "big" (e.g. 500, 100MB, 2GB)`},
+		{"pushed:2020", 7, `pushed: invalid date "2020" (want YYYY-MM-DD)`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		var qErr *Error
+		if !errors.As(err, &qErr) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tt.query, err)
+			continue
+		}
+		if qErr.Pos != tt.pos || qErr.Msg != tt.msg {
+			t.Errorf("Parse(%q) = %d %q, want %d %q", tt.query, qErr.Pos, qErr.Msg, tt.pos, tt.msg)
+		}
+	}
+}
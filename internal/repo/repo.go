@@ -2,6 +2,7 @@ package repo
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -10,6 +11,7 @@ import (
 type Repo struct {
 	Name            string    `json:"name"`
 	FullName        string    `json:"nameWithOwner"`
+	Owner           string    `json:"owner"`
 	Description     string    `json:"description"`
 	URL             string    `json:"url"`
 	SSHURL          string    `json:"sshUrl"`
@@ -25,22 +27,60 @@ type Repo struct {
 	UpdatedAt       time.Time `json:"updatedAt"`
 	PushedAt        time.Time `json:"pushedAt"`
 	DiskUsage       int       `json:"diskUsage"` // in KB
-	Selected        bool      // for multi-select in TUI
+	Topics          []string  `json:"topics"`
+	License         string    `json:"license"` // SPDX id, empty when unlicensed
+	DefaultBranch   string    `json:"defaultBranch"`
+	HomepageURL     string    `json:"homepageUrl"`
+	IsMirror        bool      `json:"isMirror"`
+	IsDisabled      bool      `json:"isDisabled"`
+	IsLocked        bool      `json:"isLocked"`
+	Visibility      string    `json:"visibility"` // PUBLIC, PRIVATE or INTERNAL
+	// HasProtectMarker is set when the default branch has a ProtectMarker file
+	HasProtectMarker bool `json:"hasProtectMarker"`
+
+	// Activity signals; zero times mean there was none
+	LastCommitAt  time.Time `json:"lastCommitAt"` // author date of the default branch head
+	LastIssueAt   time.Time `json:"lastIssueAt"`  // most recently updated issue
+	LastPRAt      time.Time `json:"lastPullRequestAt"`
+	LastReleaseAt time.Time `json:"lastReleaseAt"`
+	OpenPRCount   int       `json:"openPullRequests"`
+	Contributors  int       `json:"contributors"` // -1 until fetched
+	Dependents    int       `json:"dependents"`   // repositories that depend on it; -1 until fetched
+
+	Selected bool `json:"-"` // for multi-select in TUI
+}
+
+// ProtectMarker is a file whose presence in a repository's root protects it
+// from archive and delete
+const ProtectMarker = ".gh-repo-review-protect"
+
+// Matcher is a compiled search query, such as one parsed by the query package
+type Matcher interface {
+	Match(r Repo) bool
+	String() string
 }
 
 // FilterOptions holds the filter criteria
 type FilterOptions struct {
-	ShowArchived     bool
-	ShowPrivate      bool
-	ShowPublic       bool
-	ShowForks        bool
-	Language         string
-	MinStars         int
-	MaxStars         int
-	InactiveForDays  int // repos not updated in X days
-	SearchQuery      string
-	SortBy           SortField
-	SortDesc         bool
+	ShowArchived    bool
+	ShowPrivate     bool
+	ShowPublic      bool
+	ShowForks       bool
+	OnlyForks       bool
+	OnlyArchived    bool
+	OnlyInternal    bool
+	Language        string
+	License         string // SPDX id, or "none" for unlicensed repos
+	MinStars        int
+	MaxStars        int
+	InactiveForDays int            // repos not updated in X days
+	InactiveBy      ActivitySignal // what counts as an update
+	SearchQuery     string
+	Topics          []string // repo must have at least one of these
+	ExcludeTopics   []string // repo must have none of these
+	Query           Matcher  // replaces the SearchQuery substring match when set
+	SortBy          SortField
+	SortDesc        bool
 }
 
 // SortField represents sortable fields
@@ -53,6 +93,15 @@ const (
 	SortByStars
 	SortByForks
 	SortBySize
+	SortByActivity
+	SortByCommitted
+	SortByReleased
+	SortByPullRequests
+	SortByContributors
+	SortByScore
+
+	// NumSortFields is the number of sort fields, for cycling through them
+	NumSortFields = iota
 )
 
 func (s SortField) String() string {
@@ -69,11 +118,57 @@ func (s SortField) String() string {
 		return "Forks"
 	case SortBySize:
 		return "Size"
+	case SortByActivity:
+		return "Last Activity"
+	case SortByCommitted:
+		return "Last Commit"
+	case SortByReleased:
+		return "Last Release"
+	case SortByPullRequests:
+		return "Open PRs"
+	case SortByContributors:
+		return "Contributors"
+	case SortByScore:
+		return "Archive Score"
 	default:
 		return "Unknown"
 	}
 }
 
+// sortFieldNames maps command-line names to sort fields
+var sortFieldNames = map[string]SortField{
+	"name":         SortByName,
+	"updated":      SortByUpdated,
+	"created":      SortByCreated,
+	"stars":        SortByStars,
+	"forks":        SortByForks,
+	"size":         SortBySize,
+	"activity":     SortByActivity,
+	"committed":    SortByCommitted,
+	"released":     SortByReleased,
+	"prs":          SortByPullRequests,
+	"contributors": SortByContributors,
+	"score":        SortByScore,
+}
+
+// ParseSortField converts a name such as "stars" into a SortField
+func ParseSortField(name string) (SortField, error) {
+	if s, ok := sortFieldNames[strings.ToLower(name)]; ok {
+		return s, nil
+	}
+	return SortByName, fmt.Errorf("unknown sort field %q (want name, updated, created, stars, forks, size, activity, committed, released, prs, contributors or score)", name)
+}
+
+// Name returns the command-line name of the sort field, the inverse of ParseSortField
+func (s SortField) Name() string {
+	for name, field := range sortFieldNames {
+		if field == s {
+			return name
+		}
+	}
+	return "updated"
+}
+
 // DefaultFilterOptions returns sensible default filters
 func DefaultFilterOptions() FilterOptions {
 	return FilterOptions{
@@ -118,12 +213,26 @@ func Filter(repos []Repo, opts FilterOptions) []Repo {
 		if r.IsFork && !opts.ShowForks {
 			continue
 		}
+		if !r.IsFork && opts.OnlyForks {
+			continue
+		}
+		if !r.IsArchived && opts.OnlyArchived {
+			continue
+		}
+		if !r.IsInternal() && opts.OnlyInternal {
+			continue
+		}
 
 		// Language filter
 		if opts.Language != "" && !strings.EqualFold(r.PrimaryLanguage, opts.Language) {
 			continue
 		}
 
+		// License filter
+		if opts.License != "" && !r.HasLicense(opts.License) {
+			continue
+		}
+
 		// Stars filter
 		if opts.MinStars >= 0 && r.StargazerCount < opts.MinStars {
 			continue
@@ -133,12 +242,24 @@ func Filter(repos []Repo, opts FilterOptions) []Repo {
 		}
 
 		// Inactivity filter
-		if opts.InactiveForDays > 0 && r.PushedAt.After(cutoff) {
+		if opts.InactiveForDays > 0 && r.LastActivity(opts.InactiveBy).After(cutoff) {
+			continue
+		}
+
+		// Topic filters
+		if len(opts.Topics) > 0 && !r.HasAnyTopic(opts.Topics) {
+			continue
+		}
+		if r.HasAnyTopic(opts.ExcludeTopics) {
 			continue
 		}
 
 		// Search query
-		if opts.SearchQuery != "" {
+		if opts.Query != nil {
+			if !opts.Query.Match(r) {
+				continue
+			}
+		} else if opts.SearchQuery != "" {
 			query := strings.ToLower(opts.SearchQuery)
 			name := strings.ToLower(r.Name)
 			desc := strings.ToLower(r.Description)
@@ -153,8 +274,172 @@ func Filter(repos []Repo, opts FilterOptions) []Repo {
 	return result
 }
 
+// MatchReasons explains which active filter criteria a repo satisfies.
+// It assumes the repo passed Filter with the same options.
+func MatchReasons(r Repo, opts FilterOptions) []string {
+	var reasons []string
+
+	if opts.InactiveForDays > 0 {
+		reasons = append(reasons, fmt.Sprintf("no %s for %d days (inactive > %d days)", opts.InactiveBy.Noun(), r.DaysSinceActivity(opts.InactiveBy), opts.InactiveForDays))
+	}
+	if opts.Language != "" {
+		reasons = append(reasons, fmt.Sprintf("language is %s", r.PrimaryLanguage))
+	}
+	if opts.License != "" {
+		reasons = append(reasons, fmt.Sprintf("license is %s", r.LicenseString()))
+	}
+	if opts.MinStars >= 0 {
+		reasons = append(reasons, fmt.Sprintf("%d stars (min %d)", r.StargazerCount, opts.MinStars))
+	}
+	if opts.MaxStars >= 0 {
+		reasons = append(reasons, fmt.Sprintf("%d stars (max %d)", r.StargazerCount, opts.MaxStars))
+	}
+	if !opts.ShowForks {
+		reasons = append(reasons, "not a fork")
+	}
+	if opts.OnlyForks {
+		reasons = append(reasons, "fork")
+	}
+	if opts.ShowPrivate != opts.ShowPublic || opts.OnlyInternal {
+		reasons = append(reasons, strings.ToLower(r.VisibilityString()))
+	}
+	if r.IsArchived && opts.ShowArchived {
+		reasons = append(reasons, "archived")
+	}
+	if opts.Query != nil {
+		if q := opts.Query.String(); q != "" {
+			reasons = append(reasons, fmt.Sprintf("matches query %q", q))
+		}
+	} else if opts.SearchQuery != "" {
+		reasons = append(reasons, fmt.Sprintf("name or description matches %q", opts.SearchQuery))
+	}
+	if len(opts.Topics) > 0 {
+		reasons = append(reasons, fmt.Sprintf("has topic %s", strings.Join(matchingTopics(r, opts.Topics), ", ")))
+	}
+	if len(opts.ExcludeTopics) > 0 {
+		reasons = append(reasons, fmt.Sprintf("no topic %s", strings.Join(opts.ExcludeTopics, ", ")))
+	}
+
+	if len(reasons) == 0 {
+		reasons = append(reasons, "no filters active")
+	}
+	return reasons
+}
+
+// DescribeFilter summarizes the active filter criteria in plain words
+func DescribeFilter(opts FilterOptions) []string {
+	var parts []string
+	switch {
+	case !opts.ShowPrivate && !opts.ShowPublic:
+		parts = append(parts, "no visibility selected")
+	case !opts.ShowPrivate:
+		parts = append(parts, "public only")
+	case !opts.ShowPublic:
+		parts = append(parts, "private only")
+	}
+	if opts.ShowArchived {
+		parts = append(parts, "including archived")
+	}
+	if !opts.ShowForks {
+		parts = append(parts, "excluding forks")
+	}
+	if opts.OnlyForks {
+		parts = append(parts, "forks only")
+	}
+	if opts.OnlyArchived {
+		parts = append(parts, "archived only")
+	}
+	if opts.OnlyInternal {
+		parts = append(parts, "internal only")
+	}
+	if opts.Language != "" {
+		parts = append(parts, "language "+opts.Language)
+	}
+	if opts.License != "" {
+		parts = append(parts, "license "+opts.License)
+	}
+	if opts.MinStars >= 0 {
+		parts = append(parts, fmt.Sprintf("at least %d stars", opts.MinStars))
+	}
+	if opts.MaxStars >= 0 {
+		parts = append(parts, fmt.Sprintf("at most %d stars", opts.MaxStars))
+	}
+	if opts.InactiveForDays > 0 {
+		parts = append(parts, fmt.Sprintf("no %s for > %d days", opts.InactiveBy.Noun(), opts.InactiveForDays))
+	}
+	if opts.Query != nil {
+		if q := opts.Query.String(); q != "" {
+			parts = append(parts, fmt.Sprintf("query %q", q))
+		}
+	} else if opts.SearchQuery != "" {
+		parts = append(parts, fmt.Sprintf("search %q", opts.SearchQuery))
+	}
+	if len(opts.Topics) > 0 {
+		parts = append(parts, "topic "+strings.Join(opts.Topics, " or "))
+	}
+	if len(opts.ExcludeTopics) > 0 {
+		parts = append(parts, "without topic "+strings.Join(opts.ExcludeTopics, " or "))
+	}
+	return parts
+}
+
+// HasAnyTopic reports whether the repo has at least one of topics
+func (r Repo) HasAnyTopic(topics []string) bool {
+	return len(matchingTopics(r, topics)) > 0
+}
+
+// topicPattern is what GitHub accepts as a topic
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ValidTopic reports whether GitHub accepts name as a topic
+func ValidTopic(name string) bool {
+	return topicPattern.MatchString(name)
+}
+
+// EditTopics returns topics with add appended and remove taken out,
+// without duplicates
+func EditTopics(topics, add, remove []string) []string {
+	drop := make(map[string]bool)
+	for _, t := range remove {
+		drop[strings.ToLower(t)] = true
+	}
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, t := range append(append([]string{}, topics...), add...) {
+		t = strings.ToLower(t)
+		if !drop[t] && !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// matchingTopics returns the repo topics that appear in topics
+func matchingTopics(r Repo, topics []string) []string {
+	var matched []string
+	for _, t := range r.Topics {
+		for _, want := range topics {
+			if strings.EqualFold(t, want) {
+				matched = append(matched, t)
+				break
+			}
+		}
+	}
+	return matched
+}
+
 // Sort sorts repos by the specified field
 func Sort(repos []Repo, sortBy SortField, desc bool) {
+	// Scores are computed once rather than on every comparison
+	var scores map[string]int
+	if sortBy == SortByScore {
+		scores = make(map[string]int, len(repos))
+		for _, r := range repos {
+			scores[r.FullName] = r.ArchiveScore().Total
+		}
+	}
+
 	n := len(repos)
 	for i := 0; i < n-1; i++ {
 		for j := 0; j < n-i-1; j++ {
@@ -172,6 +457,18 @@ func Sort(repos []Repo, sortBy SortField, desc bool) {
 				swap = repos[j].ForkCount < repos[j+1].ForkCount
 			case SortBySize:
 				swap = repos[j].DiskUsage < repos[j+1].DiskUsage
+			case SortByActivity:
+				swap = repos[j].LastActivity(ActivityAny).Before(repos[j+1].LastActivity(ActivityAny))
+			case SortByCommitted:
+				swap = repos[j].LastCommitAt.Before(repos[j+1].LastCommitAt)
+			case SortByReleased:
+				swap = repos[j].LastReleaseAt.Before(repos[j+1].LastReleaseAt)
+			case SortByPullRequests:
+				swap = repos[j].OpenPRCount < repos[j+1].OpenPRCount
+			case SortByContributors:
+				swap = repos[j].Contributors < repos[j+1].Contributors
+			case SortByScore:
+				swap = scores[repos[j].FullName] < scores[repos[j+1].FullName]
 			}
 			if desc {
 				swap = !swap
@@ -188,6 +485,108 @@ func (r Repo) DaysSinceUpdate() int {
 	return int(time.Since(r.PushedAt).Hours() / 24)
 }
 
+// NeedsContributors reports whether opts sort or query on contributor counts,
+// which are fetched separately from the repository list
+func NeedsContributors(opts FilterOptions) bool {
+	if opts.SortBy == SortByContributors {
+		return true
+	}
+	return opts.Query != nil && strings.Contains(strings.ToLower(opts.Query.String()), "contributors:")
+}
+
+// ActivitySignal selects what counts as activity for the inactivity filter
+type ActivitySignal int
+
+const (
+	// ActivityAny is the latest push, commit, issue, pull request or release
+	ActivityAny ActivitySignal = iota
+	ActivityPush
+	ActivityCommit
+	ActivityIssues
+	ActivityPullRequests
+	ActivityRelease
+)
+
+// activitySignalNames maps command-line names to signals
+var activitySignalNames = map[string]ActivitySignal{
+	"any":     ActivityAny,
+	"push":    ActivityPush,
+	"commit":  ActivityCommit,
+	"issues":  ActivityIssues,
+	"prs":     ActivityPullRequests,
+	"release": ActivityRelease,
+}
+
+// ParseActivitySignal converts a name such as "commit" into an ActivitySignal
+func ParseActivitySignal(name string) (ActivitySignal, error) {
+	if s, ok := activitySignalNames[strings.ToLower(name)]; ok {
+		return s, nil
+	}
+	return ActivityAny, fmt.Errorf("unknown activity signal %q (want any, push, commit, issues, prs or release)", name)
+}
+
+// Name returns the command-line name of the signal
+func (s ActivitySignal) Name() string {
+	for name, signal := range activitySignalNames {
+		if signal == s {
+			return name
+		}
+	}
+	return "any"
+}
+
+// Noun describes the signal in reasons, e.g. "no commit for 400 days"
+func (s ActivitySignal) Noun() string {
+	switch s {
+	case ActivityPush:
+		return "push"
+	case ActivityCommit:
+		return "commit"
+	case ActivityIssues:
+		return "issue activity"
+	case ActivityPullRequests:
+		return "pull request activity"
+	case ActivityRelease:
+		return "release"
+	default:
+		return "activity"
+	}
+}
+
+// LastActivity returns the time of the latest activity of the given kind.
+// A repo that never had any counts from its creation. ActivityAny includes
+// the last push, so it never finds a repo more inactive than ActivityPush.
+func (r Repo) LastActivity(signal ActivitySignal) time.Time {
+	var t time.Time
+	switch signal {
+	case ActivityPush:
+		t = r.PushedAt
+	case ActivityCommit:
+		t = r.LastCommitAt
+	case ActivityIssues:
+		t = r.LastIssueAt
+	case ActivityPullRequests:
+		t = r.LastPRAt
+	case ActivityRelease:
+		t = r.LastReleaseAt
+	default:
+		for _, s := range []time.Time{r.PushedAt, r.LastCommitAt, r.LastIssueAt, r.LastPRAt, r.LastReleaseAt} {
+			if s.After(t) {
+				t = s
+			}
+		}
+	}
+	if t.IsZero() {
+		t = r.CreatedAt
+	}
+	return t
+}
+
+// DaysSinceActivity returns the number of days since the latest activity of the given kind
+func (r Repo) DaysSinceActivity(signal ActivitySignal) int {
+	return int(time.Since(r.LastActivity(signal)).Hours() / 24)
+}
+
 // SizeString returns a human-readable size string
 func (r Repo) SizeString() string {
 	kb := r.DiskUsage
@@ -202,14 +601,56 @@ func (r Repo) SizeString() string {
 	return fmt.Sprintf("%.2f GB", gb)
 }
 
-// VisibilityString returns "Private" or "Public"
+// OwnerLogin returns the owner, falling back to the nameWithOwner prefix
+func (r Repo) OwnerLogin() string {
+	if r.Owner != "" {
+		return r.Owner
+	}
+	if i := strings.Index(r.FullName, "/"); i >= 0 {
+		return r.FullName[:i]
+	}
+	return ""
+}
+
+// VisibilityString returns "Public", "Private" or "Internal". Internal repos
+// also report IsPrivate, so Visibility is checked first.
 func (r Repo) VisibilityString() string {
+	switch r.Visibility {
+	case "INTERNAL":
+		return "Internal"
+	case "PRIVATE":
+		return "Private"
+	case "PUBLIC":
+		return "Public"
+	}
 	if r.IsPrivate {
 		return "Private"
 	}
 	return "Public"
 }
 
+// IsInternal reports whether the repo is visible to the whole enterprise
+func (r Repo) IsInternal() bool {
+	return r.Visibility == "INTERNAL"
+}
+
+// LicenseString returns the license or "None"
+func (r Repo) LicenseString() string {
+	if r.License == "" {
+		return "None"
+	}
+	return r.License
+}
+
+// HasLicense reports whether the repo has the license, where "none" matches
+// unlicensed repos
+func (r Repo) HasLicense(license string) bool {
+	if strings.EqualFold(license, "none") {
+		return r.License == ""
+	}
+	return strings.EqualFold(r.License, license)
+}
+
 // StatusString returns archive/fork/template status
 func (r Repo) StatusString() string {
 	var parts []string
@@ -222,6 +663,15 @@ func (r Repo) StatusString() string {
 	if r.IsTemplate {
 		parts = append(parts, "Template")
 	}
+	if r.IsMirror {
+		parts = append(parts, "Mirror")
+	}
+	if r.IsDisabled {
+		parts = append(parts, "Disabled")
+	}
+	if r.IsLocked {
+		parts = append(parts, "Locked")
+	}
 	if len(parts) == 0 {
 		return "-"
 	}
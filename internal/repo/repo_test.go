@@ -0,0 +1,55 @@
+// ABOUTME: Tests for repository activity signals and the inactivity filter.
+// ABOUTME: Guards that the default signal never finds a repo more inactive than its pushes.
+
+package repo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastActivity(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	created := now.AddDate(-3, 0, 0)
+	pushed := now.AddDate(0, -1, 0)
+	committed := now.AddDate(-2, 0, 0)
+	r := Repo{CreatedAt: created, PushedAt: pushed, LastCommitAt: committed}
+
+	tests := []struct {
+		signal ActivitySignal
+		want   time.Time
+	}{
+		{ActivityAny, pushed},
+		{ActivityPush, pushed},
+		{ActivityCommit, committed},
+		{ActivityRelease, created},
+	}
+	for _, tt := range tests {
+		t.Run(tt.signal.Name(), func(t *testing.T) {
+			if got := r.LastActivity(tt.signal); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInactiveFilterDefaultIncludesPush(t *testing.T) {
+	now := time.Now()
+	recentPush := Repo{
+		FullName:     "a/pushed",
+		CreatedAt:    now.AddDate(-3, 0, 0),
+		PushedAt:     now.AddDate(0, 0, -10),
+		LastCommitAt: now.AddDate(-2, 0, 0),
+	}
+	opts := DefaultFilterOptions()
+	opts.ShowForks = true
+	opts.InactiveForDays = 365
+	if got := Filter([]Repo{recentPush}, opts); len(got) != 0 {
+		t.Errorf("a repo pushed 10 days ago counted as inactive for 365 days")
+	}
+	stale := recentPush
+	stale.PushedAt = now.AddDate(-2, 0, 0)
+	if got := Filter([]Repo{stale}, opts); len(got) != 1 {
+		t.Errorf("a repo without activity for 2 years was not counted as inactive")
+	}
+}
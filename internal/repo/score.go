@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ScoreFactor is one component of an archive candidate score
+type ScoreFactor struct {
+	Name   string
+	Points float64
+	Max    float64
+	Detail string
+	// Unknown is set when the data for the factor wasn't available, so it
+	// added nothing to the score
+	Unknown bool
+}
+
+// Score rates how good an archive candidate a repo is, from 0 to 100
+type Score struct {
+	Total   int
+	Factors []ScoreFactor // largest contribution first
+}
+
+// Maximum points per factor; they add up to 100
+const (
+	scoreInactivity = 40
+	scoreStars      = 15
+	scoreForks      = 10
+	scoreIssues     = 10
+	scoreSize       = 10
+	scoreFork       = 15
+
+	// scoreDependents is the most points dependents take off; a repo others
+	// depend on is a poor archive candidate however inactive it is
+	scoreDependents = 30
+
+	// scoreInactiveDays is the inactivity that earns the full inactivity points
+	scoreInactiveDays = 730
+)
+
+// ArchiveScore combines inactivity, popularity, open work, size and fork
+// status into a single score, less points for dependents. Dependents are only
+// counted on demand, so the dependents factor is usually unknown.
+func (r Repo) ArchiveScore() Score {
+	days := r.DaysSinceActivity(ActivityAny)
+	open := r.OpenIssuesCount + r.OpenPRCount
+	factors := []ScoreFactor{
+		{
+			Name:   "inactivity",
+			Points: scoreInactivity * math.Min(float64(days)/scoreInactiveDays, 1),
+			Max:    scoreInactivity,
+			Detail: fmt.Sprintf("no activity for %d days", days),
+		},
+		{
+			Name:   "stars",
+			Points: scoreStars * fade(r.StargazerCount, 2),
+			Max:    scoreStars,
+			Detail: fmt.Sprintf("%d stars", r.StargazerCount),
+		},
+		{
+			Name:   "forks",
+			Points: scoreForks * fade(r.ForkCount, 1.5),
+			Max:    scoreForks,
+			Detail: fmt.Sprintf("%d forks", r.ForkCount),
+		},
+		{
+			Name:   "open work",
+			Points: scoreIssues * fade(open, 1.5),
+			Max:    scoreIssues,
+			Detail: fmt.Sprintf("%d open issues and PRs", open),
+		},
+		{
+			Name:   "size",
+			Points: scoreSize * fade(r.DiskUsage/1024, 2),
+			Max:    scoreSize,
+			Detail: r.SizeString(),
+		},
+	}
+	if r.IsFork {
+		factors = append(factors, ScoreFactor{Name: "fork", Points: scoreFork, Max: scoreFork, Detail: "fork"})
+	}
+	if r.Dependents < 0 {
+		factors = append(factors, ScoreFactor{Name: "dependents", Detail: "dependents unknown", Unknown: true})
+	} else {
+		factors = append(factors, ScoreFactor{
+			Name:   "dependents",
+			Points: -scoreDependents * (1 - fade(r.Dependents, 2)),
+			Detail: fmt.Sprintf("%d dependents", r.Dependents),
+		})
+	}
+
+	total := 0.0
+	for _, f := range factors {
+		total += f.Points
+	}
+	sort.SliceStable(factors, func(i, j int) bool { return factors[i].Points > factors[j].Points })
+	return Score{Total: int(math.Round(math.Max(total, 0))), Factors: factors}
+}
+
+// fade is 1 for n = 0 and falls to 0 at n = 10^decades - 1 on a log scale,
+// so the first few stars or forks count the most
+func fade(n int, decades float64) float64 {
+	if n <= 0 {
+		return 1
+	}
+	return 1 - math.Min(math.Log10(float64(n+1))/decades, 1)
+}
+
+// TopFactors returns up to n factors that contributed points
+func (s Score) TopFactors(n int) []ScoreFactor {
+	var top []ScoreFactor
+	for _, f := range s.Factors {
+		if len(top) == n {
+			break
+		}
+		if f.Points >= 0.5 {
+			top = append(top, f)
+		}
+	}
+	return top
+}
+
+// Penalties returns the factors that took points off
+func (s Score) Penalties() []ScoreFactor {
+	var penalties []ScoreFactor
+	for _, f := range s.Factors {
+		if f.Points <= -0.5 {
+			penalties = append(penalties, f)
+		}
+	}
+	return penalties
+}
+
+// Unknown returns the names of factors left out for lack of data
+func (s Score) Unknown() []string {
+	var names []string
+	for _, f := range s.Factors {
+		if f.Unknown {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// Recommend returns the unarchived repos with the highest archive scores,
+// highest first and at most limit
+func Recommend(repos []Repo, limit int) []Repo {
+	var candidates []Repo
+	scores := make(map[string]int)
+	for _, r := range repos {
+		if !r.IsArchived {
+			candidates = append(candidates, r)
+			scores[r.FullName] = r.ArchiveScore().Total
+		}
+	}
+	// Sorted here rather than with Sort, whose direction for numeric fields
+	// is the reverse of what its desc flag suggests
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].FullName] > scores[candidates[j].FullName]
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
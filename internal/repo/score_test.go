@@ -0,0 +1,118 @@
+// ABOUTME: Tests for the archive candidate score and the recommendation ranking.
+// ABOUTME: Covers factor ordering, score extremes and the limit cutoff of Recommend.
+
+package repo
+
+import (
+	"testing"
+	"time"
+)
+
+// deadFork is about as good an archive candidate as a repo gets
+func deadFork(name string) Repo {
+	old := time.Now().AddDate(-5, 0, 0)
+	return Repo{FullName: name, IsFork: true, CreatedAt: old, PushedAt: old}
+}
+
+// popular is active, starred and forked, so a poor archive candidate
+func popular(name string) Repo {
+	now := time.Now()
+	return Repo{
+		FullName:        name,
+		CreatedAt:       now.AddDate(-1, 0, 0),
+		PushedAt:        now,
+		LastCommitAt:    now,
+		StargazerCount:  500,
+		ForkCount:       80,
+		OpenIssuesCount: 40,
+		DiskUsage:       200 * 1024,
+	}
+}
+
+func TestArchiveScoreExtremes(t *testing.T) {
+	if got := deadFork("a/dead").ArchiveScore().Total; got != 100 {
+		t.Errorf("dead fork scored %d, want 100", got)
+	}
+	if got := popular("a/popular").ArchiveScore().Total; got > 20 {
+		t.Errorf("popular repo scored %d, want at most 20", got)
+	}
+}
+
+func TestArchiveScoreDependents(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependents int
+		total      int
+		unknown    bool
+	}{
+		{"unknown adds nothing", -1, 100, true},
+		{"none", 0, 100, false},
+		{"some", 9, 85, false},
+		{"many", 500, 70, false},
+	}
+	for _, tt := range tests {
+		r := deadFork("a/dead")
+		r.Dependents = tt.dependents
+		score := r.ArchiveScore()
+		if score.Total != tt.total {
+			t.Errorf("%s: scored %d, want %d", tt.name, score.Total, tt.total)
+		}
+		if got := len(score.Unknown()) > 0; got != tt.unknown {
+			t.Errorf("%s: unknown = %v, want %v", tt.name, got, tt.unknown)
+		}
+		if got := len(score.Penalties()) > 0; got != (tt.dependents > 0) {
+			t.Errorf("%s: penalties %v", tt.name, score.Penalties())
+		}
+	}
+
+	used := popular("a/used")
+	used.Dependents = 1000
+	if got := used.ArchiveScore().Total; got != 0 {
+		t.Errorf("popular repo with dependents scored %d, want 0", got)
+	}
+}
+
+func TestArchiveScoreFactorsSorted(t *testing.T) {
+	factors := deadFork("a/dead").ArchiveScore().Factors
+	for i := 1; i < len(factors); i++ {
+		if factors[i].Points > factors[i-1].Points {
+			t.Fatalf("factor %s (%.1f) ranked after %s (%.1f)",
+				factors[i].Name, factors[i].Points, factors[i-1].Name, factors[i-1].Points)
+		}
+	}
+	if top := popular("a/popular").ArchiveScore().TopFactors(3); len(top) > 3 {
+		t.Errorf("TopFactors(3) returned %d factors", len(top))
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	midAge := time.Now().AddDate(-1, 0, 0)
+	middling := Repo{FullName: "a/middling", CreatedAt: midAge, PushedAt: midAge, StargazerCount: 3}
+	archived := deadFork("a/archived")
+	archived.IsArchived = true
+	repos := []Repo{popular("a/popular"), middling, archived, deadFork("a/dead")}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{"all", 0, []string{"a/dead", "a/middling", "a/popular"}},
+		{"limit cuts the lowest scores", 2, []string{"a/dead", "a/middling"}},
+		{"limit 1 keeps the best candidate", 1, []string{"a/dead"}},
+		{"limit above count", 10, []string{"a/dead", "a/middling", "a/popular"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Recommend(repos, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d repos, want %d", len(got), len(tt.want))
+			}
+			for i, r := range got {
+				if r.FullName != tt.want[i] {
+					t.Errorf("position %d: got %s, want %s", i, r.FullName, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/audit"
+)
+
+type auditLoadedMsg struct {
+	entries []audit.Entry
+	err     error
+}
+
+// loadAuditLog reads the audit log from disk
+func loadAuditLog() tea.Msg {
+	entries, err := audit.Read()
+	return auditLoadedMsg{entries: entries, err: err}
+}
+
+// openAuditLog switches to the audit log view and loads entries
+func (m *Model) openAuditLog() tea.Cmd {
+	m.view = ViewAuditLog
+	m.auditOffset = 0
+	m.auditInput.SetValue("")
+	m.auditInput.Blur()
+	return loadAuditLog
+}
+
+// filteredAudit returns entries matching the filter text, newest first
+func (m Model) filteredAudit() []audit.Entry {
+	return audit.Filter(m.auditEntries, audit.Query{Text: m.auditInput.Value()})
+}
+
+// handleAuditLogKeys handles keys in the audit log view
+func (m Model) handleAuditLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.auditInput.Focused() {
+		switch msg.String() {
+		case "enter", "esc":
+			m.auditInput.Blur()
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.auditInput, cmd = m.auditInput.Update(msg)
+			m.auditOffset = 0
+			return m, cmd
+		}
+	}
+
+	switch msg.String() {
+	case "esc", "q", "L":
+		m.view = ViewList
+	case "/":
+		m.auditInput.Focus()
+		return m, textinput.Blink
+	case "up", "k":
+		if m.auditOffset > 0 {
+			m.auditOffset--
+		}
+	case "down", "j":
+		if m.auditOffset < len(m.filteredAudit())-1 {
+			m.auditOffset++
+		}
+	case "r":
+		return m, loadAuditLog
+	}
+	return m, nil
+}
+
+func (m Model) viewAuditLog() string {
+	var b strings.Builder
+
+	entries := m.filteredAudit()
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Audit log | %d %s ", len(entries), pluralize(len(entries), "entry", "entries"))))
+	b.WriteString("\n")
+	if path, err := audit.Path(); err == nil {
+		b.WriteString(statsStyle.Render(path))
+	}
+	b.WriteString("\n\n")
+
+	if m.auditInput.Focused() || m.auditInput.Value() != "" {
+		b.WriteString(filterInputStyle.Render(m.auditInput.View()))
+		b.WriteString("\n\n")
+	}
+
+	if m.auditErr != nil {
+		b.WriteString(dangerStyle.Render("  Failed to read audit log: " + m.auditErr.Error()))
+		b.WriteString("\n")
+	} else if len(entries) == 0 {
+		b.WriteString(mutedStyle.Render("  No audit entries."))
+		b.WriteString("\n")
+	}
+
+	visible := m.visibleRows()
+	if visible < 1 {
+		visible = 10
+	}
+	end := m.auditOffset + visible
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	for _, e := range entries[m.auditOffset:end] {
+		result := successStyle.Render("✓")
+		if e.Result == audit.ResultError {
+			result = dangerStyle.Render("✗")
+		}
+		line := fmt.Sprintf("  %s %s %-10s %-9s %s",
+			result,
+			statsStyle.Render(e.Time.Local().Format("2006-01-02 15:04")),
+			e.Actor,
+			e.Action,
+			repoNameStyle.Render(e.Repo))
+		if details := e.DetailString(); details != "" {
+			line += " " + statsStyle.Render(truncate(details, 60))
+		}
+		if e.Error != "" {
+			line += " " + mutedStyle.Render(truncate(e.Error, 50))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	helpItems := []string{
+		helpKeyStyle.Render("/") + " filter",
+		helpKeyStyle.Render("j/k") + " scroll",
+		helpKeyStyle.Render("r") + " reload",
+		helpKeyStyle.Render("esc") + " back",
+	}
+	b.WriteString(helpStyle.Render(strings.Join(helpItems, "  ")))
+
+	return appStyle.Render(b.String())
+}
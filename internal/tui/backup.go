@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/audit"
+	"github.com/user/gh-repo-review/internal/backup"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// backupState tracks a repo through its backup
+type backupState int
+
+const (
+	backupPending backupState = iota
+	backupRunning
+	backupDone
+	backupFailed
+)
+
+type backupStatus struct {
+	state backupState
+	dir   string
+	err   error
+}
+
+type backupCompleteMsg struct {
+	name   string
+	result backup.Result
+	err    error
+}
+
+// startBackups queues every selected repo for backup-then-delete
+func (m *Model) startBackups() tea.Cmd {
+	m.backupOrder = nil
+	m.backupStatus = make(map[string]backupStatus)
+	for _, r := range m.repos {
+		if r.Selected {
+			m.backupOrder = append(m.backupOrder, r.FullName)
+			m.backupStatus[r.FullName] = backupStatus{state: backupPending}
+		}
+	}
+	return m.nextBackup()
+}
+
+// nextBackup starts the next pending backup. Backups run one at a time
+// because mirror clones are heavy on disk and network.
+func (m *Model) nextBackup() tea.Cmd {
+	for _, name := range m.backupOrder {
+		st := m.backupStatus[name]
+		if st.state != backupPending {
+			continue
+		}
+		st.state = backupRunning
+		m.backupStatus[name] = st
+
+		client := m.client
+		dir := m.backupDir
+		return func() tea.Msg {
+			if client == nil {
+				client = gh.NewClient()
+			}
+			result, err := backup.Run(client, name, dir)
+			return backupCompleteMsg{name: name, result: result, err: err}
+		}
+	}
+	return nil
+}
+
+// handleBackupComplete records a backup result. Once every backup has
+// finished, the repos backed up successfully are deleted as a bulk job.
+func (m *Model) handleBackupComplete(msg backupCompleteMsg) tea.Cmd {
+	st := m.backupStatus[msg.name]
+	st.dir = msg.result.Dir
+	if msg.err != nil {
+		st.state = backupFailed
+		st.err = msg.err
+		_ = m.auditLogger().Record("delete", msg.name, m.priorState(msg.name), nil, fmt.Errorf("backup failed, not deleted: %w", msg.err))
+	} else {
+		st.state = backupDone
+	}
+	m.backupStatus[msg.name] = st
+
+	if next := m.nextBackup(); next != nil {
+		return next
+	}
+	return m.deleteBackedUp()
+}
+
+// deleteBackedUp starts the delete job for the repos whose backup succeeded
+func (m *Model) deleteBackedUp() tea.Cmd {
+	backedUp := make(map[string]bool)
+	failed := 0
+	for _, name := range m.backupOrder {
+		if m.backupStatus[name].state == backupDone {
+			backedUp[name] = true
+		} else {
+			failed++
+		}
+	}
+	m.backupOrder = nil
+	m.backupStatus = nil
+
+	cmd := m.startJob("delete", func(r repo.Repo) bool { return backedUp[r.FullName] })
+	if failed > 0 {
+		m.job.note = fmt.Sprintf("%d %s not deleted because the backup failed", failed, pluralize(failed, "repository was", "repositories were"))
+	}
+	for i := range m.repos {
+		m.repos[i].Selected = false
+	}
+	m.updateSelectedCount()
+	return cmd
+}
+
+// priorState returns the audit state of a loaded repo, or nil if unknown
+func (m Model) priorState(fullName string) *audit.State {
+	for _, r := range m.repos {
+		if r.FullName == fullName {
+			return audit.StateOf(r)
+		}
+	}
+	return nil
+}
+
+// backupStatusLine renders the backup state of one repo for the delete dialog
+func (m Model) backupStatusLine(name string) string {
+	st, ok := m.backupStatus[name]
+	if !ok {
+		return mutedStyle.Render("· backup pending")
+	}
+	switch st.state {
+	case backupRunning:
+		return warningStyle.Render("… backing up")
+	case backupDone:
+		return successStyle.Render("✓ backed up")
+	case backupFailed:
+		return dangerStyle.Render("✗ backup failed, kept")
+	default:
+		return mutedStyle.Render("· backup pending")
+	}
+}
+
+// viewBackupProgress renders per-repo backup status inside the delete dialog
+func (m Model) viewBackupProgress() string {
+	var b strings.Builder
+
+	done := 0
+	for _, name := range m.backupOrder {
+		st := m.backupStatus[name]
+		b.WriteString(fmt.Sprintf("  • %s  %s\n", name, m.backupStatusLine(name)))
+		if st.state == backupFailed && st.err != nil {
+			b.WriteString(mutedStyle.Render("      "+truncate(st.err.Error(), 60)) + "\n")
+		}
+		if st.state == backupDone || st.state == backupFailed {
+			done++
+		}
+	}
+
+	b.WriteString("\n")
+	current := done + 1
+	if current > len(m.backupOrder) {
+		current = len(m.backupOrder)
+	}
+	b.WriteString(warningStyle.Render(fmt.Sprintf("Backing up %d/%d to %s", current, len(m.backupOrder), m.backupDir)))
+	b.WriteString("\n" + mutedStyle.Render("Repositories are deleted once every backup has finished."))
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/config"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// newDeleteInput creates the text input for typed delete confirmation
+func newDeleteInput() textinput.Model {
+	ti := textinput.New()
+	ti.CharLimit = 140
+	ti.Width = 40
+	return ti
+}
+
+// openConfirmDelete shows the delete dialog for the selected repos
+func (m *Model) openConfirmDelete() tea.Cmd {
+	m.view = ViewConfirmDelete
+	m.deleteOverride = false
+	m.deleteErr = ""
+	m.deleteInput.SetValue("")
+	m.deleteInput.Placeholder = m.deletePhrase()
+	load := m.loadDependents()
+	if !m.typedDelete() {
+		m.deleteInput.Blur()
+		return load
+	}
+	m.deleteInput.Focus()
+	return tea.Batch(load, textinput.Blink)
+}
+
+// loadDependents counts the dependents of delete targets when the
+// dependents limit is on, skipping repos already tried
+func (m *Model) loadDependents() tea.Cmd {
+	if m.client == nil || m.dependentsLoading || m.config.Delete.MaxDependents < 0 {
+		return nil
+	}
+	if m.dependentCounts == nil {
+		m.dependentCounts = make(map[string]int)
+	}
+	var names []string
+	for _, r := range m.deleteTargets() {
+		if _, tried := m.dependentCounts[r.FullName]; !tried && r.Dependents < 0 {
+			names = append(names, r.FullName)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	m.dependentsLoading = true
+	client := m.client
+	return func() tea.Msg {
+		return dependentsLoadedMsg{names: names, counts: client.GetDependentsCounts(names)}
+	}
+}
+
+// dependentsUnknown counts the delete targets whose dependents couldn't be
+// counted while the dependents limit is on
+func (m Model) dependentsUnknown() int {
+	if m.client == nil || m.config.Delete.MaxDependents < 0 {
+		return 0
+	}
+	unknown := 0
+	for _, r := range m.deleteTargets() {
+		if r.Dependents < 0 {
+			unknown++
+		}
+	}
+	return unknown
+}
+
+// typedDelete reports whether the delete must be confirmed by typing. Dry
+// runs change nothing, so a single y is enough for them.
+func (m Model) typedDelete() bool {
+	return m.config.Delete.Confirm != config.ConfirmSimple && !m.dryRun
+}
+
+// deleteKeys returns the delete dialog's confirm, backup, override and cancel
+// keys. While typing, the single-key bindings become ctrl keys.
+func (m Model) deleteKeys() (yKey, bKey, oKey, nKey string) {
+	if m.typedDelete() {
+		return "enter", "ctrl+b", "ctrl+o", "esc"
+	}
+	return "y", "b", "o", "n"
+}
+
+// deleteTargets returns the selected repos that aren't protected
+func (m Model) deleteTargets() []repo.Repo {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if r.Selected && m.protectedReason(r) == "" {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+// deletePhrase is what must be typed to confirm: the full name of a single
+// repo, or a phrase with the count for several
+func (m Model) deletePhrase() string {
+	targets := m.deleteTargets()
+	if len(targets) == 1 {
+		return targets[0].FullName
+	}
+	return fmt.Sprintf("delete %d repositories", len(targets))
+}
+
+// deleteConfirmed reports whether the typed text confirms the delete. Bulk
+// deletes also accept the bare count.
+func (m Model) deleteConfirmed() bool {
+	typed := strings.TrimSpace(m.deleteInput.Value())
+	if typed == m.deletePhrase() {
+		return true
+	}
+	n := len(m.deleteTargets())
+	return n > 1 && typed == strconv.Itoa(n)
+}
+
+// deleteBlocked counts the selected repos above the delete limits
+func (m Model) deleteBlocked() int {
+	blocked := 0
+	for _, r := range m.deleteTargets() {
+		if len(m.config.Delete.Blockers(r)) > 0 {
+			blocked++
+		}
+	}
+	return blocked
+}
+
+// confirmDelete runs the delete once the guard and confirmation pass
+func (m *Model) confirmDelete() tea.Cmd {
+	if len(m.deleteTargets()) == 0 {
+		m.deleteErr = "Every selected repository is protected"
+		return nil
+	}
+	m.deselectProtected()
+	if m.dryRun {
+		m.showPlan("delete", func(r repo.Repo) bool { return r.Selected })
+		return nil
+	}
+	if m.dependentsLoading {
+		m.deleteErr = "Still counting dependents; try again in a moment"
+		return nil
+	}
+	if n := m.deleteBlocked(); n > 0 && !m.deleteOverride {
+		_, _, oKey, _ := m.deleteKeys()
+		m.deleteErr = fmt.Sprintf("%d %s above the delete limits; override with %s", n, pluralize(n, "repository is", "repositories are"), oKey)
+		return nil
+	}
+	if m.typedDelete() && !m.deleteConfirmed() {
+		m.deleteErr = fmt.Sprintf("Type %q to confirm", m.deletePhrase())
+		return nil
+	}
+	m.deleteInput.Blur()
+	if m.backupEnabled {
+		return m.startBackups()
+	}
+	return m.startJob("delete", func(r repo.Repo) bool { return r.Selected })
+}
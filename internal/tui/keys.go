@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/config"
+)
+
+// keyMap translates remapped list view keys to the built-in keys the
+// handlers switch on
+type keyMap struct {
+	// toDefault maps a pressed key to the default key of its action; an
+	// empty value means the default key was moved elsewhere and does nothing
+	toDefault map[string]string
+	// labels maps a default key to the key shown in help
+	labels map[string]string
+}
+
+// newKeyMap builds the translation for the configured bindings
+func newKeyMap(cfg *config.Config) keyMap {
+	km := keyMap{toDefault: make(map[string]string), labels: make(map[string]string)}
+	bindings := cfg.KeyBindings()
+	bound := make(map[string]bool)
+	for _, key := range bindings {
+		bound[key] = true
+	}
+	for action, key := range bindings {
+		def := config.DefaultKeys[action]
+		if key == def {
+			continue
+		}
+		km.toDefault[key] = def
+		km.labels[def] = key
+		if !bound[def] {
+			km.toDefault[def] = ""
+		}
+	}
+	return km
+}
+
+// translate returns msg as the default key of its action, and false if the
+// key has been unbound
+func (km keyMap) translate(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	def, ok := km.toDefault[msg.String()]
+	if !ok {
+		return msg, true
+	}
+	if def == "" {
+		return msg, false
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(def)}, true
+}
+
+// label returns the configured key for a default key, e.g. for "j/k"
+func (km keyMap) label(keys string) string {
+	if keys == "/" {
+		return km.single(keys)
+	}
+	parts := strings.Split(keys, "/")
+	for i, part := range parts {
+		parts[i] = km.single(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func (km keyMap) single(key string) string {
+	if label, ok := km.labels[key]; ok {
+		return label
+	}
+	return key
+}
@@ -0,0 +1,278 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// defaultDescriptionPrefix is offered when prefixing descriptions in bulk
+const defaultDescriptionPrefix = "[DEPRECATED]"
+
+// metadataEdit is what an edit job writes to each repo
+type metadataEdit struct {
+	// descriptions holds the new description per repo
+	descriptions map[string]string
+	// homepage is the new homepage, or nil to leave it alone
+	homepage *string
+}
+
+// run returns the client call for the edit. Without a client every edit
+// succeeds without doing anything.
+func (e metadataEdit) run(client *gh.Client) func(fullName string) error {
+	if client == nil {
+		return func(string) error { return nil }
+	}
+	return func(fullName string) error {
+		description := e.descriptions[fullName]
+		return client.UpdateRepo(fullName, gh.RepoUpdate{Description: &description, Homepage: e.homepage})
+	}
+}
+
+// details records the new and previous values in the audit log
+func (e metadataEdit) details(r repo.Repo) map[string]string {
+	d := map[string]string{
+		"description":          e.descriptions[r.FullName],
+		"previous_description": r.Description,
+	}
+	if e.homepage != nil {
+		d["homepage"] = *e.homepage
+		d["previous_homepage"] = r.HomepageURL
+	}
+	return d
+}
+
+// apply reflects a successful edit in r
+func (e metadataEdit) apply(r *repo.Repo) {
+	r.Description = e.descriptions[r.FullName]
+	if e.homepage != nil {
+		r.HomepageURL = *e.homepage
+	}
+}
+
+// Fields of the edit dialog. The prefix field is the only one in bulk mode.
+const (
+	metadataDescriptionField = iota
+	metadataHomepageField
+	metadataPrefixField
+	numMetadataFields
+)
+
+// newMetadataInputs creates the text inputs of the edit dialog
+func newMetadataInputs() []textinput.Model {
+	placeholders := []string{"No description", "https://example.com", defaultDescriptionPrefix}
+	limits := []int{350, 255, 50}
+	inputs := make([]textinput.Model, numMetadataFields)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = limits[i]
+		ti.Width = 50
+		inputs[i] = ti
+	}
+	return inputs
+}
+
+// metadataTargets returns the repos the edit dialog changes: the selection in
+// bulk mode, otherwise the repo under the cursor. Archived repos are read-only.
+func (m Model) metadataTargets() []repo.Repo {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if m.metadataBulk && r.Selected && !r.IsArchived {
+			targets = append(targets, r)
+		}
+		if !m.metadataBulk && r.FullName == m.metadataRepo {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+// prefixed returns description with prefix in front, unless it is already there
+func prefixed(prefix, description string) string {
+	if strings.HasPrefix(description, prefix) {
+		return description
+	}
+	return strings.TrimSpace(prefix + " " + description)
+}
+
+// openMetadata shows the edit dialog: a description prefix for the
+// selection, or the description and homepage of the repo under the cursor
+func (m *Model) openMetadata() tea.Cmd {
+	for i := range m.metadataInputs {
+		m.metadataInputs[i].SetValue("")
+		m.metadataInputs[i].Blur()
+	}
+	m.metadataErr = ""
+	m.metadataBulk = m.selectedCount > 0
+	if m.metadataBulk {
+		m.metadataInputs[metadataPrefixField].SetValue(defaultDescriptionPrefix)
+		m.metadataFocus = metadataPrefixField
+	} else {
+		idx := m.getActualIndex(m.cursor)
+		if idx < 0 {
+			return nil
+		}
+		r := m.repos[idx]
+		if r.IsArchived {
+			m.message = r.FullName + " is archived; unarchive it to edit it"
+			return nil
+		}
+		m.metadataRepo = r.FullName
+		m.metadataInputs[metadataDescriptionField].SetValue(r.Description)
+		m.metadataInputs[metadataHomepageField].SetValue(r.HomepageURL)
+		m.metadataFocus = metadataDescriptionField
+	}
+	m.view = ViewMetadata
+	m.metadataInputs[m.metadataFocus].Focus()
+	return textinput.Blink
+}
+
+// switchMetadataFocus moves between the description and homepage fields
+func (m *Model) switchMetadataFocus() tea.Cmd {
+	if m.metadataBulk {
+		return nil
+	}
+	m.metadataInputs[m.metadataFocus].Blur()
+	if m.metadataFocus == metadataDescriptionField {
+		m.metadataFocus = metadataHomepageField
+	} else {
+		m.metadataFocus = metadataDescriptionField
+	}
+	m.metadataInputs[m.metadataFocus].Focus()
+	return textinput.Blink
+}
+
+// parseMetadata validates the dialog and returns the edit for its targets
+func (m Model) parseMetadata() (metadataEdit, error) {
+	edit := metadataEdit{descriptions: make(map[string]string)}
+	if m.metadataBulk {
+		prefix := strings.TrimSpace(m.metadataInputs[metadataPrefixField].Value())
+		if prefix == "" {
+			return edit, fmt.Errorf("enter the prefix to add")
+		}
+		for _, r := range m.metadataTargets() {
+			if d := prefixed(prefix, r.Description); d != r.Description {
+				edit.descriptions[r.FullName] = d
+			}
+		}
+		return edit, nil
+	}
+
+	homepage := strings.TrimSpace(m.metadataInputs[metadataHomepageField].Value())
+	if homepage != "" {
+		u, err := url.Parse(homepage)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return edit, fmt.Errorf("homepage must be an http or https URL")
+		}
+	}
+	edit.descriptions[m.metadataRepo] = strings.TrimSpace(m.metadataInputs[metadataDescriptionField].Value())
+	edit.homepage = &homepage
+	return edit, nil
+}
+
+// handleMetadataKeys handles the edit dialog
+func (m Model) handleMetadataKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.metadataInputs[m.metadataFocus].Blur()
+		m.view = ViewList
+		return m, nil
+
+	case "tab", "shift+tab":
+		return m, m.switchMetadataFocus()
+
+	case "enter":
+		edit, err := m.parseMetadata()
+		if err != nil {
+			m.metadataErr = err.Error()
+			return m, nil
+		}
+		if len(edit.descriptions) == 0 {
+			m.metadataErr = "Every description already has this prefix"
+			return m, nil
+		}
+		m.metadata = edit
+		m.metadataInputs[m.metadataFocus].Blur()
+		include := func(r repo.Repo) bool {
+			_, ok := edit.descriptions[r.FullName]
+			return ok
+		}
+		if m.dryRun {
+			m.showPlan("edit", include)
+			return m, nil
+		}
+		return m, m.startJob("edit", include)
+	}
+
+	var cmd tea.Cmd
+	m.metadataInputs[m.metadataFocus], cmd = m.metadataInputs[m.metadataFocus].Update(msg)
+	m.metadataErr = ""
+	return m, cmd
+}
+
+func (m Model) viewMetadata() string {
+	var b strings.Builder
+	targets := m.metadataTargets()
+
+	if m.metadataBulk {
+		b.WriteString(dialogTitleStyle.Render("✎ Prefix Descriptions"))
+		b.WriteString("\n\n")
+		prefix := strings.TrimSpace(m.metadataInputs[metadataPrefixField].Value())
+		for i, r := range targets {
+			if i == 5 {
+				b.WriteString(fmt.Sprintf("  ... and %d more\n", len(targets)-5))
+				break
+			}
+			b.WriteString(fmt.Sprintf("  • %s  %s\n", r.FullName, mutedStyle.Render(truncate(prefixed(prefix, r.Description), 50))))
+		}
+		if skipped := m.selectedCount - len(targets); skipped > 0 {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("%d archived %s skipped.", skipped, pluralize(skipped, "repository is", "repositories are"))) + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(helpKeyStyle.Render("Prefix") + " " + m.metadataInputs[metadataPrefixField].View() + "\n")
+	} else {
+		b.WriteString(dialogTitleStyle.Render("✎ Edit " + m.metadataRepo))
+		b.WriteString("\n\n")
+		labels := map[int]string{
+			metadataDescriptionField: "Description",
+			metadataHomepageField:    "Homepage",
+		}
+		for _, f := range []int{metadataDescriptionField, metadataHomepageField} {
+			label := fmt.Sprintf("%-11s", labels[f])
+			if f == m.metadataFocus {
+				label = helpKeyStyle.Render(label)
+			} else {
+				label = mutedStyle.Render(label)
+			}
+			b.WriteString(label + " " + m.metadataInputs[f].View() + "\n")
+		}
+	}
+	if m.metadataErr != "" {
+		b.WriteString(dangerStyle.Render(m.metadataErr) + "\n")
+	}
+	b.WriteString("\n")
+	if m.metadataBulk {
+		b.WriteString(mutedStyle.Render("Descriptions that already start with the prefix are left alone.\nThe selection is kept, so pressing "+m.keys.label("a")+" next archives the batch.") + "\n\n")
+	}
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will be updated.\n\n"))
+		b.WriteString(helpKeyStyle.Render("enter") + " Show plan  ")
+	} else if m.metadataBulk {
+		b.WriteString(helpKeyStyle.Render("enter") + fmt.Sprintf(" Update %d  ", len(targets)))
+	} else {
+		b.WriteString(helpKeyStyle.Render("enter") + " Save  ")
+	}
+	if !m.metadataBulk {
+		b.WriteString(helpKeyStyle.Render("tab") + " Next field  ")
+	}
+	b.WriteString(helpKeyStyle.Render("esc") + " Cancel")
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -2,15 +2,24 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user/gh-repo-review/internal/audit"
 	"github.com/user/gh-repo-review/internal/cache"
+	"github.com/user/gh-repo-review/internal/config"
 	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/plan"
+	"github.com/user/gh-repo-review/internal/policy"
+	"github.com/user/gh-repo-review/internal/query"
 	"github.com/user/gh-repo-review/internal/repo"
+	"github.com/user/gh-repo-review/internal/worker"
 )
 
 // View represents different screens in the app
@@ -22,9 +31,40 @@ const (
 	ViewDetail
 	ViewConfirmArchive
 	ViewConfirmDelete
+	ViewConfirmUnarchive
 	ViewHelp
+	ViewPlan
+	ViewAuditLog
+	ViewProgress
+	ViewRecommend
+	ViewConfirmVisibility
+	ViewTransfer
+	ViewTopics
+	ViewMetadata
 )
 
+// Options configures the TUI at startup
+type Options struct {
+	// Owners to switch between; empty means the user and their organizations
+	Owners []string
+	// Affiliations used when listing the authenticated user's repositories
+	Affiliations []string
+	// DryRun starts with destructive actions producing a plan instead
+	DryRun bool
+	// Backup enables backups before deleting, stored in BackupDir
+	Backup    bool
+	BackupDir string
+	// Concurrency is the number of parallel API mutations in bulk actions
+	Concurrency int
+	// Policy highlights repositories that violate it; nil disables highlighting
+	Policy *policy.Policy
+	// Config holds defaults, key bindings, theme and presets; nil means built-in settings
+	Config *config.Config
+	// Filters replaces the default filters at startup, named by Preset
+	Filters *repo.FilterOptions
+	Preset  string
+}
+
 // Model is the main application model
 type Model struct {
 	// Data
@@ -33,6 +73,11 @@ type Model struct {
 	client        *gh.Client
 	username      string
 
+	// Owner switching
+	owners       []string
+	ownerIndex   int
+	affiliations []string
+
 	// State
 	view           View
 	cursor         int
@@ -45,6 +90,18 @@ type Model struct {
 	// Filtering
 	filterOpts  repo.FilterOptions
 	searchInput textinput.Model
+	queryErr    error
+
+	// Configured defaults and key bindings
+	defaultFilters repo.FilterOptions
+	inactiveCycle  []int
+	keys           keyMap
+
+	// Saved filter presets
+	config       *config.Config
+	presetCursor int
+	presetInput  textinput.Model
+	presetName   string // last preset loaded or saved
 
 	// UI state
 	width      int
@@ -52,6 +109,82 @@ type Model struct {
 	spinner    spinner.Model
 	showDetail bool
 
+	// Dry-run planning
+	dryRun     bool
+	plan       plan.Plan
+	planOffset int
+
+	// Pre-delete backups
+	backupEnabled bool
+	backupDir     string
+	backupOrder   []string
+	backupStatus  map[string]backupStatus
+
+	// Audit log view
+	auditEntries []audit.Entry
+	auditErr     error
+	auditOffset  int
+	auditInput   textinput.Model
+
+	// Archive recommendations, ranked when the view opens
+	recommended     []repo.Repo
+	recommendCursor int
+
+	// Typed delete confirmation and the override for protected repos
+	deleteInput    textinput.Model
+	deleteOverride bool
+	deleteErr      string
+
+	// Visibility the visibility dialog applies: public, private or internal
+	visibilityTarget string
+
+	// Transfer dialog and the destination of the running transfer
+	transferInputs []textinput.Model
+	transferFocus  int
+	transferErr    string
+	transfer       transferSpec
+
+	// Topic editor and the edit the running topic job applies
+	topicInput textinput.Model
+	topicErr   string
+	topics     topicEdit
+
+	// Edit dialog: the description and homepage of metadataRepo, or a
+	// description prefix for the selection in bulk mode
+	metadataInputs []textinput.Model
+	metadataFocus  int
+	metadataErr    string
+	metadataBulk   bool
+	metadataRepo   string
+	metadata       metadataEdit
+
+	// Whether archives commit the README notice first, and where the repo moved
+	archiveNotice bool
+	noticeInput   textinput.Model
+
+	// Archived batches that u unarchives again, most recent last
+	undoStack [][]repo.Repo
+
+	// Bulk operation progress
+	job         *bulkJob
+	jobSeq      int
+	progressBar progress.Model
+	pool        *worker.Pool
+
+	// Retention policy and the repos violating it, by full name
+	policy     *policy.Policy
+	violations map[string]policy.Violation
+
+	// Contributor counts are fetched on demand and kept across reloads;
+	// -1 marks a count that could not be fetched
+	contributorsLoading bool
+	contributorCounts   map[string]int
+
+	// Dependents are counted for delete targets when the dependents limit
+	// is on; -1 marks a count that could not be fetched
+	dependentsLoading bool
+	dependentCounts   map[string]int
+
 	// Selection for bulk operations
 	selectedCount int
 }
@@ -60,42 +193,94 @@ type Model struct {
 type reposLoadedMsg struct {
 	repos    []repo.Repo
 	username string
+	owner    string
+	owners   []string // only set by the initial load
 }
 
 type cacheLoadedMsg struct {
 	repos    []repo.Repo
 	username string
+	owner    string
+	owners   []string // only set by the initial load
 	fresh    bool
 }
 
 type backgroundRefreshMsg struct {
 	repos []repo.Repo
+	owner string
+}
+
+type contributorsLoadedMsg struct {
+	names  []string
+	counts map[string]int
+}
+
+type dependentsLoadedMsg struct {
+	names  []string
+	counts map[string]int
 }
 
 type errorMsg struct{ err error }
-type archiveCompleteMsg struct{ name string }
-type deleteCompleteMsg struct{ name string }
 type actionMsg string
 
-// NewModel creates a new Model with default settings
-func NewModel() Model {
+// NewModel creates a new Model with the given startup options
+func NewModel(opts Options) Model {
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = config.New()
+	}
+	applyTheme(cfg.Theme)
+	cache.SetTTL(cfg.CacheTTL)
+
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
 
 	ti := textinput.New()
-	ti.Placeholder = "Type to search..."
-	ti.CharLimit = 50
-	ti.Width = 30
+	ti.Placeholder = "Search or query, e.g. lang:go stars:<5 -is:fork"
+	ti.CharLimit = 200
+	ti.Width = 50
+
+	ai := textinput.New()
+	ai.Placeholder = "Filter by repo, action, actor..."
+	ai.CharLimit = 50
+	ai.Width = 30
+
+	filters := cfg.DefaultOptions()
+	if opts.Filters != nil {
+		filters = *opts.Filters
+	}
+	ti.SetValue(filters.SearchQuery)
 
 	return Model{
-		view:       ViewList,
-		loading:    true,
-		spinner:    s,
-		filterOpts: repo.DefaultFilterOptions(),
-		searchInput: ti,
-		width:      80,
-		height:     24,
+		view:           ViewList,
+		loading:        true,
+		spinner:        s,
+		filterOpts:     filters,
+		searchInput:    ti,
+		defaultFilters: cfg.DefaultOptions(),
+		inactiveCycle:  cfg.InactiveCycle(),
+		keys:           newKeyMap(cfg),
+		config:         cfg,
+		presetInput:    newPresetInput(),
+		presetName:     opts.Preset,
+		auditInput:     ai,
+		deleteInput:    newDeleteInput(),
+		transferInputs: newTransferInputs(),
+		topicInput:     newTopicInput(),
+		metadataInputs: newMetadataInputs(),
+		archiveNotice:  cfg.ArchiveNotice.Enabled,
+		noticeInput:    newNoticeInput(),
+		progressBar:    newProgressBar(),
+		pool:           worker.New(worker.Options{Workers: opts.Concurrency}),
+		policy:         opts.Policy,
+		width:          80,
+		height:         24,
+		owners:         opts.Owners,
+		affiliations:   opts.Affiliations,
+		dryRun:         opts.DryRun,
+		backupEnabled:  opts.Backup,
+		backupDir:      opts.BackupDir,
 	}
 }
 
@@ -103,71 +288,136 @@ func NewModel() Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
-		loadReposWithCache,
+		loadReposWithCache(m.owners, m.affiliations),
 	)
 }
 
-// loadReposWithCache tries cache first, falls back to API
-func loadReposWithCache() tea.Msg {
-	client := gh.NewClient()
+// loadReposWithCache resolves the user and owners, then loads the first owner
+func loadReposWithCache(owners, affiliations []string) tea.Cmd {
+	return func() tea.Msg {
+		client := gh.NewClient()
+
+		if err := client.CheckAuth(); err != nil {
+			return errorMsg{err: fmt.Errorf("not authenticated with gh CLI: %w", err)}
+		}
 
-	if err := client.CheckAuth(); err != nil {
-		return errorMsg{err: fmt.Errorf("not authenticated with gh CLI: %w", err)}
+		username, err := client.GetCurrentUser()
+		if err != nil {
+			return errorMsg{err: err}
+		}
+
+		if len(owners) == 0 {
+			owners = []string{username}
+			// Organizations only feed the owner switcher, so failures are not fatal
+			if orgs, err := client.ListOrganizations(); err == nil {
+				owners = append(owners, orgs...)
+			}
+		} else {
+			owners = append([]string(nil), owners...)
+			for i, o := range owners {
+				if o == "@me" {
+					owners[i] = username
+				}
+			}
+		}
+
+		msg := loadOwnerRepos(client, owners[0], username, affiliations)
+		switch msg := msg.(type) {
+		case cacheLoadedMsg:
+			msg.owners = owners
+			return msg
+		case reposLoadedMsg:
+			msg.owners = owners
+			return msg
+		}
+		return msg
 	}
+}
 
-	username, err := client.GetCurrentUser()
-	if err != nil {
-		return errorMsg{err: err}
+// loadOwner loads repos for another owner, using the cache when possible
+func loadOwner(owner, username string, affiliations []string) tea.Cmd {
+	return func() tea.Msg {
+		return loadOwnerRepos(gh.NewClient(), owner, username, affiliations)
 	}
+}
+
+// loadOwnerRepos tries the cache for owner first, falls back to API
+func loadOwnerRepos(client *gh.Client, owner, username string, affiliations []string) tea.Msg {
+	key := cacheKey(owner, username, affiliations)
 
 	// Try loading from cache
-	repos, fresh, err := cache.Load(username)
+	repos, fresh, err := cache.Load(key)
 	if err == nil && repos != nil {
-		return cacheLoadedMsg{repos: repos, username: username, fresh: fresh}
+		return cacheLoadedMsg{repos: repos, username: username, owner: owner, fresh: fresh}
 	}
 
 	// No cache, fetch from API
-	repos, err = client.ListRepos()
+	repos, err = client.ListRepos(listOptions(owner, username, affiliations))
 	if err != nil {
 		return errorMsg{err: err}
 	}
 
 	// Save to cache
-	_ = cache.Save(username, repos)
+	_ = cache.Save(key, repos)
 
-	return reposLoadedMsg{repos: repos, username: username}
+	return reposLoadedMsg{repos: repos, username: username, owner: owner}
 }
 
 // refreshRepos fetches fresh data from API (for background refresh)
-func refreshRepos(username string) tea.Cmd {
+func refreshRepos(owner, username string, affiliations []string) tea.Cmd {
 	return func() tea.Msg {
 		client := gh.NewClient()
-		repos, err := client.ListRepos()
+		repos, err := client.ListRepos(listOptions(owner, username, affiliations))
 		if err != nil {
 			// Silent failure for background refresh
 			return nil
 		}
-		_ = cache.Save(username, repos)
-		return backgroundRefreshMsg{repos: repos}
+		_ = cache.Save(cacheKey(owner, username, affiliations), repos)
+		return backgroundRefreshMsg{repos: repos, owner: owner}
 	}
 }
 
 // forceRefreshRepos always fetches from API (for manual refresh)
-func forceRefreshRepos() tea.Msg {
-	client := gh.NewClient()
+func forceRefreshRepos(owner, username string, affiliations []string) tea.Cmd {
+	return func() tea.Msg {
+		client := gh.NewClient()
+		repos, err := client.ListRepos(listOptions(owner, username, affiliations))
+		if err != nil {
+			return errorMsg{err: err}
+		}
 
-	username, err := client.GetCurrentUser()
-	if err != nil {
-		return errorMsg{err: err}
+		_ = cache.Save(cacheKey(owner, username, affiliations), repos)
+		return reposLoadedMsg{repos: repos, username: username, owner: owner}
 	}
+}
 
-	repos, err := client.ListRepos()
-	if err != nil {
-		return errorMsg{err: err}
+// listOptions maps an owner login to the gh list options.
+// The user's own login uses the viewer query so affiliations apply.
+func listOptions(owner, username string, affiliations []string) gh.ListOptions {
+	if owner == username {
+		return gh.ListOptions{Affiliations: affiliations}
+	}
+	return gh.ListOptions{Owner: owner}
+}
+
+func cacheKey(owner, username string, affiliations []string) string {
+	if owner == username {
+		return cache.Key(owner, affiliations)
+	}
+	return cache.Key(owner, nil)
+}
+
+// currentOwner returns the owner whose repos are shown
+func (m Model) currentOwner() string {
+	if m.ownerIndex < len(m.owners) {
+		return m.owners[m.ownerIndex]
 	}
+	return m.username
+}
 
-	_ = cache.Save(username, repos)
-	return reposLoadedMsg{repos: repos, username: username}
+// auditLogger records actions taken in this session
+func (m Model) auditLogger() audit.Logger {
+	return audit.Logger{Actor: m.username, Source: "tui"}
 }
 
 // Update handles messages
@@ -176,7 +426,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+		model, cmd := m.handleKeyPress(msg)
+		if next, ok := model.(Model); ok {
+			if load := next.loadContributors(); load != nil {
+				return next, tea.Batch(cmd, load)
+			}
+		}
+		return model, cmd
+
+	case contributorsLoadedMsg:
+		m.contributorsLoading = false
+		for _, name := range msg.names {
+			n, ok := msg.counts[name]
+			if !ok {
+				n = -1
+			}
+			m.contributorCounts[name] = n
+		}
+		m.applyCounts()
+		m.applyFilters()
+		if strings.HasPrefix(m.message, "Counting contributors") {
+			m.message = fmt.Sprintf("Counted contributors of %d repositories", len(msg.counts))
+		}
+
+	case dependentsLoadedMsg:
+		m.dependentsLoading = false
+		for _, name := range msg.names {
+			n, ok := msg.counts[name]
+			if !ok {
+				n = -1
+			}
+			m.dependentCounts[name] = n
+		}
+		m.applyCounts()
+		m.applyFilters()
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -191,28 +474,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case reposLoadedMsg:
+		if msg.owners != nil {
+			m.owners = msg.owners
+			m.ownerIndex = 0
+		}
+		if msg.owner != m.currentOwner() {
+			// Stale load for an owner we switched away from
+			break
+		}
 		m.loading = false
 		m.repos = msg.repos
 		m.username = msg.username
 		m.client = gh.NewClient()
+		m.applyCounts()
 		m.applyFilters()
 		m.message = fmt.Sprintf("Loaded %d repositories", len(m.repos))
 
 	case cacheLoadedMsg:
+		if msg.owners != nil {
+			m.owners = msg.owners
+			m.ownerIndex = 0
+		}
+		if msg.owner != m.currentOwner() {
+			break
+		}
 		m.loading = false
 		m.repos = msg.repos
 		m.username = msg.username
 		m.client = gh.NewClient()
+		m.applyCounts()
 		m.applyFilters()
 		if msg.fresh {
 			m.message = fmt.Sprintf("Loaded %d repositories (cached)", len(m.repos))
 		} else {
 			m.message = fmt.Sprintf("Loaded %d repositories (refreshing...)", len(m.repos))
-			cmds = append(cmds, refreshRepos(msg.username))
+			cmds = append(cmds, refreshRepos(msg.owner, msg.username, m.affiliations))
 		}
 
 	case backgroundRefreshMsg:
-		if msg.repos != nil {
+		if msg.repos != nil && msg.owner == m.currentOwner() {
 			// Preserve selection state
 			selectedNames := make(map[string]bool)
 			for _, r := range m.repos {
@@ -226,6 +526,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.repos[i].Selected = true
 				}
 			}
+			m.applyCounts()
 			m.applyFilters()
 			m.message = fmt.Sprintf("Refreshed %d repositories", len(m.repos))
 		}
@@ -236,30 +537,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.message = msg.err.Error()
 		m.messageIsError = true
 
-	case archiveCompleteMsg:
-		m.message = fmt.Sprintf("Archived: %s", msg.name)
-		m.messageIsError = false
-		// Mark as archived in our list
-		for i := range m.repos {
-			if m.repos[i].FullName == msg.name {
-				m.repos[i].IsArchived = true
-				m.repos[i].Selected = false
-				break
-			}
-		}
-		m.applyFilters()
+	case jobEventMsg:
+		m.handleJobEvent(msg)
+		cmds = append(cmds, msg.next)
 
-	case deleteCompleteMsg:
-		m.message = fmt.Sprintf("Deleted: %s", msg.name)
-		m.messageIsError = false
-		// Remove from our list
-		for i := range m.repos {
-			if m.repos[i].FullName == msg.name {
-				m.repos = append(m.repos[:i], m.repos[i+1:]...)
-				break
-			}
+	case jobTickMsg:
+		// Keep the elapsed time moving until the job finishes
+		if m.job != nil && m.job.id == msg.jobID && !m.job.done() {
+			cmds = append(cmds, jobTick(msg.jobID))
 		}
-		m.applyFilters()
+
+	case auditLoadedMsg:
+		m.auditEntries = msg.entries
+		m.auditErr = msg.err
+
+	case backupCompleteMsg:
+		cmds = append(cmds, m.handleBackupComplete(msg))
 
 	case actionMsg:
 		m.message = string(msg)
@@ -271,6 +564,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Remapped keys only apply to the list, outside the search box
+	if m.view == ViewList && !m.searchInput.Focused() {
+		var ok bool
+		if msg, ok = m.keys.translate(msg); !ok {
+			return m, nil
+		}
+	}
+
 	// Global keys
 	switch msg.String() {
 	case "ctrl+c", "q":
@@ -291,8 +592,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleConfirmArchiveKeys(msg)
 	case ViewConfirmDelete:
 		return m.handleConfirmDeleteKeys(msg)
+	case ViewConfirmUnarchive:
+		return m.handleConfirmUnarchiveKeys(msg)
 	case ViewHelp:
 		return m.handleHelpKeys(msg)
+	case ViewPlan:
+		return m.handlePlanKeys(msg)
+	case ViewAuditLog:
+		return m.handleAuditLogKeys(msg)
+	case ViewProgress:
+		return m.handleProgressKeys(msg)
+	case ViewRecommend:
+		return m.handleRecommendKeys(msg)
+	case ViewConfirmVisibility:
+		return m.handleConfirmVisibilityKeys(msg)
+	case ViewTransfer:
+		return m.handleTransferKeys(msg)
+	case ViewTopics:
+		return m.handleTopicKeys(msg)
+	case ViewMetadata:
+		return m.handleMetadataKeys(msg)
 	}
 
 	return m, nil
@@ -304,14 +623,12 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "enter", "esc":
 			m.searchInput.Blur()
-			m.filterOpts.SearchQuery = m.searchInput.Value()
-			m.applyFilters()
+			m.setSearch(m.searchInput.Value())
 			return m, nil
 		default:
 			var cmd tea.Cmd
 			m.searchInput, cmd = m.searchInput.Update(msg)
-			m.filterOpts.SearchQuery = m.searchInput.Value()
-			m.applyFilters()
+			m.setSearch(m.searchInput.Value())
 			return m, cmd
 		}
 	}
@@ -383,7 +700,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else {
 				// Archive single repo
 				idx := m.getActualIndex(m.cursor)
-				if idx >= 0 {
+				if idx >= 0 && !m.refuseProtected(m.repos[idx]) {
 					m.repos[idx].Selected = true
 					m.updateSelectedCount()
 					m.view = ViewConfirmArchive
@@ -394,30 +711,85 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "d":
 		if len(m.filteredRepos) > 0 {
 			if m.selectedCount > 0 {
-				m.view = ViewConfirmDelete
-			} else {
-				// Delete single repo under cursor
-				idx := m.getActualIndex(m.cursor)
-				if idx >= 0 {
-					m.repos[idx].Selected = true
-					m.updateSelectedCount()
-					m.view = ViewConfirmDelete
-				}
+				return m, m.openConfirmDelete()
+			}
+			// Delete single repo under cursor
+			idx := m.getActualIndex(m.cursor)
+			if idx >= 0 && !m.refuseProtected(m.repos[idx]) {
+				m.repos[idx].Selected = true
+				m.updateSelectedCount()
+				return m, m.openConfirmDelete()
 			}
 		}
 
+	case "U":
+		if len(m.filteredRepos) > 0 {
+			m.confirmUnarchive()
+		}
+
+	case "V":
+		if len(m.filteredRepos) > 0 {
+			m.openConfirmVisibility()
+		}
+
+	case "T":
+		if len(m.filteredRepos) > 0 {
+			return m, m.openTransfer()
+		}
+
+	case "t":
+		if len(m.filteredRepos) > 0 {
+			return m, m.openTopics()
+		}
+
+	case "e":
+		if len(m.filteredRepos) > 0 {
+			return m, m.openMetadata()
+		}
+
 	case "A":
-		// Select all visible
+		// Select all visible, leaving protected repos out
+		protected := 0
 		for i := range m.filteredRepos {
-			if !m.filteredRepos[i].IsArchived {
+			if m.filteredRepos[i].IsArchived {
+				continue
+			}
+			if m.protectedReason(m.filteredRepos[i]) != "" {
+				protected++
+				continue
+			}
+			m.filteredRepos[i].Selected = true
+			idx := m.getActualIndex(i)
+			if idx >= 0 {
+				m.repos[idx].Selected = true
+			}
+		}
+		m.updateSelectedCount()
+		if protected > 0 {
+			m.message = fmt.Sprintf("Left %d protected %s unselected", protected, pluralize(protected, "repository", "repositories"))
+			m.messageIsError = false
+		}
+
+	case "P":
+		// Select visible repos that violate the policy
+		if m.policy == nil {
+			m.message = "No policy loaded (see --policy)"
+			m.messageIsError = true
+			break
+		}
+		count := 0
+		for i := range m.filteredRepos {
+			if _, ok := m.violations[m.filteredRepos[i].FullName]; ok {
 				m.filteredRepos[i].Selected = true
-				idx := m.getActualIndex(i)
-				if idx >= 0 {
+				if idx := m.getActualIndex(i); idx >= 0 {
 					m.repos[idx].Selected = true
 				}
+				count++
 			}
 		}
 		m.updateSelectedCount()
+		m.message = fmt.Sprintf("Selected %d policy %s", count, pluralize(count, "violation", "violations"))
+		m.messageIsError = false
 
 	case "D":
 		// Deselect all
@@ -446,11 +818,42 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "r":
 		m.loading = true
-		return m, forceRefreshRepos
+		return m, forceRefreshRepos(m.currentOwner(), m.username, m.affiliations)
+
+	case "O":
+		// Switch to the next owner
+		if len(m.owners) > 1 {
+			m.ownerIndex = (m.ownerIndex + 1) % len(m.owners)
+			m.repos = nil
+			m.filteredRepos = nil
+			m.selectedCount = 0
+			m.cursor = 0
+			m.offset = 0
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, loadOwner(m.currentOwner(), m.username, m.affiliations))
+		}
 
 	case "?":
 		m.view = ViewHelp
 
+	case "L":
+		return m, m.openAuditLog()
+
+	case "R":
+		m.openRecommendations()
+
+	case "u":
+		return m, m.undoArchive()
+
+	case "p":
+		m.dryRun = !m.dryRun
+		if m.dryRun {
+			m.message = "Dry run on: archive and delete only produce a plan"
+		} else {
+			m.message = "Dry run off: archive and delete change repositories"
+		}
+		m.messageIsError = false
+
 	case "1":
 		m.filterOpts.ShowArchived = !m.filterOpts.ShowArchived
 		m.applyFilters()
@@ -470,6 +873,10 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleFilterKeys handles keys in filter view
 func (m Model) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if handled, cmd := m.handlePresetKeys(msg); handled {
+		return m, cmd
+	}
+
 	switch msg.String() {
 	case "esc", "q", "f":
 		m.view = ViewList
@@ -486,7 +893,10 @@ func (m Model) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filterOpts.ShowForks = !m.filterOpts.ShowForks
 		m.applyFilters()
 	case "5":
-		m.filterOpts.InactiveForDays = cycleInactiveDays(m.filterOpts.InactiveForDays)
+		m.filterOpts.InactiveForDays = cycleInactiveDays(m.filterOpts.InactiveForDays, m.inactiveCycle)
+		m.applyFilters()
+	case "6":
+		m.filterOpts.InactiveBy = (m.filterOpts.InactiveBy + 1) % (repo.ActivityRelease + 1)
 		m.applyFilters()
 	case "s":
 		m.cycleSortField()
@@ -495,8 +905,9 @@ func (m Model) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filterOpts.SortDesc = !m.filterOpts.SortDesc
 		m.applyFilters()
 	case "r":
-		m.filterOpts = repo.DefaultFilterOptions()
+		m.filterOpts = m.defaultFilters
 		m.searchInput.SetValue("")
+		m.presetName = ""
 		m.applyFilters()
 	}
 	return m, nil
@@ -521,30 +932,75 @@ func (m Model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.view = ViewConfirmArchive
 			}
 		}
+	case "U":
+		if len(m.filteredRepos) > 0 {
+			m.confirmUnarchive()
+		}
 	}
 	return m, nil
 }
 
-// handleConfirmArchiveKeys handles the archive confirmation dialog
-func (m Model) handleConfirmArchiveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// confirmUnarchive opens the unarchive dialog for the selection, or the
+// repo under the cursor when nothing is selected
+func (m *Model) confirmUnarchive() {
+	if m.selectedCount == 0 {
+		idx := m.getActualIndex(m.cursor)
+		if idx < 0 {
+			return
+		}
+		if !m.repos[idx].IsArchived {
+			m.message = fmt.Sprintf("%s is not archived", m.repos[idx].FullName)
+			m.messageIsError = true
+			return
+		}
+		m.repos[idx].Selected = true
+		m.updateSelectedCount()
+	}
+	m.view = ViewConfirmUnarchive
+}
+
+// handleConfirmUnarchiveKeys handles the unarchive confirmation dialog
+func (m Model) handleConfirmUnarchiveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
-		var cmds []tea.Cmd
+		if m.dryRun {
+			m.showPlan("unarchive", func(r repo.Repo) bool { return r.Selected && r.IsArchived })
+			return m, nil
+		}
+		return m, m.startJob("unarchive", func(r repo.Repo) bool { return r.Selected && r.IsArchived })
+
+	case "n", "N", "esc", "q":
 		for i := range m.repos {
-			if m.repos[i].Selected && !m.repos[i].IsArchived {
-				name := m.repos[i].FullName
-				cmds = append(cmds, func() tea.Msg {
-					if m.client != nil {
-						if err := m.client.ArchiveRepo(name); err != nil {
-							return errorMsg{err: err}
-						}
-					}
-					return archiveCompleteMsg{name: name}
-				})
-			}
+			m.repos[i].Selected = false
 		}
+		m.selectedCount = 0
 		m.view = ViewList
-		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// handleConfirmArchiveKeys handles the archive confirmation dialog
+func (m Model) handleConfirmArchiveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.noticeInput.Focused() {
+		return m.handleNoticeInputKeys(msg)
+	}
+	switch msg.String() {
+	case "m":
+		m.archiveNotice = !m.archiveNotice
+
+	case "M":
+		if m.archiveNotice {
+			m.noticeInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "y", "Y":
+		m.deselectProtected()
+		if m.dryRun {
+			m.showPlan("archive", func(r repo.Repo) bool { return r.Selected && !r.IsArchived })
+			return m, nil
+		}
+		return m, m.startJob("archive", func(r repo.Repo) bool { return r.Selected && !r.IsArchived })
 
 	case "n", "N", "esc", "q":
 		// Clear selections and go back
@@ -559,35 +1015,95 @@ func (m Model) handleConfirmArchiveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmDeleteKeys handles the delete confirmation dialog
 func (m Model) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		var cmds []tea.Cmd
-		for i := range m.repos {
-			if m.repos[i].Selected {
-				name := m.repos[i].FullName
-				cmds = append(cmds, func() tea.Msg {
-					if m.client != nil {
-						if err := m.client.DeleteRepo(name); err != nil {
-							return errorMsg{err: err}
-						}
-					}
-					return deleteCompleteMsg{name: name}
-				})
-			}
+	if len(m.backupOrder) > 0 {
+		// Backups in progress; the delete job starts when they finish
+		return m, nil
+	}
+
+	key := msg.String()
+	if m.typedDelete() {
+		// Letters go to the confirmation input, so options use ctrl keys
+		switch key {
+		case "ctrl+b":
+			key = "b"
+		case "ctrl+o":
+			key = "o"
+		case "enter":
+			key = "y"
+		case "esc":
+		default:
+			var cmd tea.Cmd
+			m.deleteInput, cmd = m.deleteInput.Update(msg)
+			m.deleteErr = ""
+			return m, cmd
 		}
-		m.view = ViewList
-		return m, tea.Batch(cmds...)
+	}
+
+	switch key {
+	case "b":
+		m.backupEnabled = !m.backupEnabled
+		return m, nil
+
+	case "o":
+		m.deleteOverride = !m.deleteOverride
+		m.deleteErr = ""
+		return m, nil
+
+	case "y", "Y":
+		return m, m.confirmDelete()
 
 	case "n", "N", "esc", "q":
 		for i := range m.repos {
 			m.repos[i].Selected = false
 		}
 		m.selectedCount = 0
+		m.deleteInput.Blur()
+		m.view = ViewList
+	}
+	return m, nil
+}
+
+// handlePlanKeys handles keys in the dry-run plan view
+func (m Model) handlePlanKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		// Keep the selection so the plan can be executed after sign-off
 		m.view = ViewList
+	case "up", "k":
+		if m.planOffset > 0 {
+			m.planOffset--
+		}
+	case "down", "j":
+		if m.planOffset < len(m.plan.Items)-1 {
+			m.planOffset++
+		}
+	case "w":
+		path, err := m.plan.Save()
+		if err != nil {
+			m.message = fmt.Sprintf("Failed to save plan: %v", err)
+			m.messageIsError = true
+		} else {
+			m.message = fmt.Sprintf("Plan saved to %s", path)
+			m.messageIsError = false
+		}
 	}
 	return m, nil
 }
 
+// showPlan builds a dry-run plan for the repos matching include
+func (m *Model) showPlan(action string, include func(repo.Repo) bool) {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if include(r) {
+			targets = append(targets, r)
+		}
+	}
+	m.plan = plan.New(action, targets, m.filterOpts)
+	m.planOffset = 0
+	m.message = ""
+	m.view = ViewPlan
+}
+
 // handleHelpKeys handles keys in help view
 func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -599,10 +1115,40 @@ func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // Helper methods
 
+// setSearch parses the search box as a query. While it doesn't parse, the
+// previous query stays active and the error is shown in the status line.
+func (m *Model) setSearch(text string) {
+	q, err := query.Parse(text)
+	if err != nil {
+		m.queryErr = err
+		m.message = err.Error()
+		m.messageIsError = true
+		return
+	}
+	if m.queryErr != nil {
+		m.queryErr = nil
+		m.message = ""
+		m.messageIsError = false
+	}
+	m.filterOpts.SearchQuery = text
+	m.filterOpts.Query = q
+	m.applyFilters()
+}
+
 func (m *Model) applyFilters() {
 	m.filteredRepos = repo.Filter(m.repos, m.filterOpts)
 	repo.Sort(m.filteredRepos, m.filterOpts.SortBy, m.filterOpts.SortDesc)
 
+	if m.policy != nil {
+		m.violations = make(map[string]policy.Violation)
+		for _, v := range m.policy.Evaluate(m.repos) {
+			if m.protectedReason(v.Repo) != "" {
+				continue
+			}
+			m.violations[v.Repo.FullName] = v
+		}
+	}
+
 	// Ensure cursor is valid
 	if m.cursor >= len(m.filteredRepos) {
 		m.cursor = len(m.filteredRepos) - 1
@@ -661,11 +1207,59 @@ func (m Model) findFilteredIndex(fullName string) int {
 }
 
 func (m *Model) cycleSortField() {
-	m.filterOpts.SortBy = (m.filterOpts.SortBy + 1) % 6
+	m.filterOpts.SortBy = (m.filterOpts.SortBy + 1) % repo.NumSortFields
+}
+
+// loadContributors fetches contributor counts the current sort, query or
+// detail view needs and hasn't tried yet
+func (m *Model) loadContributors() tea.Cmd {
+	if m.client == nil || m.contributorsLoading {
+		return nil
+	}
+	var candidates []repo.Repo
+	if repo.NeedsContributors(m.filterOpts) {
+		candidates = m.repos
+	} else if m.view == ViewDetail && m.cursor < len(m.filteredRepos) {
+		candidates = m.filteredRepos[m.cursor : m.cursor+1]
+	}
+
+	if m.contributorCounts == nil {
+		m.contributorCounts = make(map[string]int)
+	}
+	var names []string
+	for _, r := range candidates {
+		if _, tried := m.contributorCounts[r.FullName]; !tried && r.Contributors < 0 {
+			names = append(names, r.FullName)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	m.contributorsLoading = true
+	if len(names) > 1 {
+		m.message = fmt.Sprintf("Counting contributors of %d repositories...", len(names))
+		m.messageIsError = false
+	}
+	client := m.client
+	return func() tea.Msg {
+		return contributorsLoadedMsg{names: names, counts: client.GetContributorCounts(names)}
+	}
+}
+
+// applyCounts copies fetched contributor and dependents counts into m.repos
+func (m *Model) applyCounts() {
+	for i := range m.repos {
+		if n, ok := m.contributorCounts[m.repos[i].FullName]; ok && n >= 0 {
+			m.repos[i].Contributors = n
+		}
+		if n, ok := m.dependentCounts[m.repos[i].FullName]; ok && n >= 0 {
+			m.repos[i].Dependents = n
+		}
+	}
 }
 
-func cycleInactiveDays(current int) int {
-	options := []int{0, 30, 90, 180, 365, 730}
+func cycleInactiveDays(current int, options []int) int {
 	for i, opt := range options {
 		if opt == current {
 			return options[(i+1)%len(options)]
@@ -674,6 +1268,34 @@ func cycleInactiveDays(current int) int {
 	return 0
 }
 
+// formatDate renders a date with its age, or "-" for none
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d days ago)", t.Format("Jan 02, 2006"), int(time.Since(t).Hours()/24))
+}
+
+// scoreString renders an archive score, naming the factors left out
+func scoreString(s repo.Score) string {
+	text := fmt.Sprintf("%d/100", s.Total)
+	if unknown := s.Unknown(); len(unknown) > 0 {
+		text += fmt.Sprintf(" (%s not counted)", strings.Join(unknown, ", "))
+	}
+	return text
+}
+
+// contributorsString renders the contributor count, which is fetched on demand
+func (m Model) contributorsString(r repo.Repo) string {
+	if r.Contributors >= 0 {
+		return fmt.Sprintf("%d", r.Contributors)
+	}
+	if _, tried := m.contributorCounts[r.FullName]; tried && !m.contributorsLoading {
+		return "unknown"
+	}
+	return "counting..."
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -716,8 +1338,26 @@ func (m Model) View() string {
 		return m.viewConfirmArchive()
 	case ViewConfirmDelete:
 		return m.viewConfirmDelete()
+	case ViewConfirmUnarchive:
+		return m.viewConfirmUnarchive()
 	case ViewHelp:
 		return m.viewHelp()
+	case ViewPlan:
+		return m.viewPlan()
+	case ViewAuditLog:
+		return m.viewAuditLog()
+	case ViewProgress:
+		return m.viewProgress()
+	case ViewRecommend:
+		return m.viewRecommendations()
+	case ViewConfirmVisibility:
+		return m.viewConfirmVisibility()
+	case ViewTransfer:
+		return m.viewTransfer()
+	case ViewTopics:
+		return m.viewTopics()
+	case ViewMetadata:
+		return m.viewMetadata()
 	}
 
 	return ""
@@ -727,8 +1367,20 @@ func (m Model) viewList() string {
 	var b strings.Builder
 
 	// Title
-	title := fmt.Sprintf(" gh-repo-review | %s | %d repos ", m.username, len(m.filteredRepos))
-	b.WriteString(titleStyle.Render(title))
+	owner := m.currentOwner()
+	if len(m.owners) > 1 {
+		owner = fmt.Sprintf("◂ %s ▸ (%d/%d)", owner, m.ownerIndex+1, len(m.owners))
+	}
+	title := fmt.Sprintf(" gh-repo-review | %s | %d repos ", owner, len(m.filteredRepos))
+	// Join so tags sit beside the title rather than below its margin
+	header := []string{titleStyle.Render(title)}
+	if m.dryRun {
+		header = append(header, dryRunTagStyle.Render("DRY RUN"))
+	}
+	if len(m.violations) > 0 {
+		header = append(header, policyTagStyle.Render(fmt.Sprintf("%d policy %s", len(m.violations), pluralize(len(m.violations), "violation", "violations"))))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, header...))
 	b.WriteString("\n")
 
 	// Quick filter status
@@ -750,6 +1402,9 @@ func (m Model) viewList() string {
 	if len(filters) > 0 {
 		filterLine += " | Filters: " + strings.Join(filters, ", ")
 	}
+	if m.presetName != "" {
+		filterLine += " | Preset: " + m.presetName
+	}
 	b.WriteString(statsStyle.Render(filterLine))
 	b.WriteString("\n\n")
 
@@ -775,6 +1430,14 @@ func (m Model) viewList() string {
 		b.WriteString(mutedStyle.Render("  Press 'f' to adjust filters or 'r' to reload.\n"))
 	}
 
+	// Owner column width across visible rows
+	ownerWidth := 0
+	for i := m.offset; i < end; i++ {
+		if w := len(m.filteredRepos[i].OwnerLogin()); w > ownerWidth {
+			ownerWidth = w
+		}
+	}
+
 	for i := m.offset; i < end; i++ {
 		r := m.filteredRepos[i]
 
@@ -804,15 +1467,26 @@ func (m Model) viewList() string {
 
 		// Tags
 		var tagParts []string
-		if r.IsPrivate {
+		if r.IsInternal() {
+			tagParts = append(tagParts, internalTagStyle.Render("internal"))
+		} else if r.IsPrivate {
 			tagParts = append(tagParts, privateTagStyle.Render("private"))
 		}
 		if r.IsArchived {
 			tagParts = append(tagParts, archivedTagStyle.Render("archived"))
 		}
+		if r.IsDisabled || r.IsLocked {
+			tagParts = append(tagParts, archivedTagStyle.Render("locked"))
+		}
 		if r.IsFork {
 			tagParts = append(tagParts, forkTagStyle.Render("fork"))
 		}
+		if m.protectedReason(r) != "" {
+			tagParts = append(tagParts, protectedTagStyle.Render("🔒 protected"))
+		}
+		if v, ok := m.violations[r.FullName]; ok {
+			tagParts = append(tagParts, policyTagStyle.Render("policy: "+v.Action))
+		}
 		tags := ""
 		if len(tagParts) > 0 {
 			tags = " " + strings.Join(tagParts, " ")
@@ -825,11 +1499,12 @@ func (m Model) viewList() string {
 		if r.PrimaryLanguage != "" {
 			statParts = append(statParts, GetLangStyle(r.PrimaryLanguage).Render(r.PrimaryLanguage))
 		}
-		statParts = append(statParts, fmt.Sprintf("%dd", r.DaysSinceUpdate()))
+		statParts = append(statParts, fmt.Sprintf("%dd", r.DaysSinceActivity(m.filterOpts.InactiveBy)))
 		stats := statsStyle.Render(strings.Join(statParts, " "))
 
 		// Build line without lipgloss padding (causes issues with ANSI codes)
-		b.WriteString(fmt.Sprintf("  %s %s %s%s  %s\n", cursor, checkbox, name, tags, stats))
+		owner := mutedStyle.Render(fmt.Sprintf("%-*s", ownerWidth, r.OwnerLogin()))
+		b.WriteString(fmt.Sprintf("  %s %s %s %s%s  %s\n", cursor, checkbox, owner, name, tags, stats))
 	}
 
 	// Selection count
@@ -851,14 +1526,14 @@ func (m Model) viewList() string {
 	// Help line
 	b.WriteString("\n\n")
 	helpItems := []string{
-		helpKeyStyle.Render("/") + " search",
-		helpKeyStyle.Render("f") + " filter",
+		helpKeyStyle.Render(m.keys.label("/")) + " search",
+		helpKeyStyle.Render(m.keys.label("f")) + " filter",
 		helpKeyStyle.Render("space") + " select",
-		helpKeyStyle.Render("a") + " archive",
-		helpKeyStyle.Render("d") + " delete",
-		helpKeyStyle.Render("o") + " open",
-		helpKeyStyle.Render("?") + " help",
-		helpKeyStyle.Render("q") + " quit",
+		helpKeyStyle.Render(m.keys.label("a")) + " archive",
+		helpKeyStyle.Render(m.keys.label("d")) + " delete",
+		helpKeyStyle.Render(m.keys.label("o")) + " open",
+		helpKeyStyle.Render(m.keys.label("?")) + " help",
+		helpKeyStyle.Render(m.keys.label("q")) + " quit",
 	}
 	b.WriteString(helpStyle.Render(strings.Join(helpItems, "  ")))
 
@@ -899,6 +1574,7 @@ func (m Model) viewFilter() string {
 		inactiveStr = fmt.Sprintf("> %d days inactive", m.filterOpts.InactiveForDays)
 	}
 	b.WriteString(fmt.Sprintf("  %s Inactive: %s\n", helpKeyStyle.Render("5"), inactiveStr))
+	b.WriteString(fmt.Sprintf("  %s Activity: %s\n", helpKeyStyle.Render("6"), m.filterOpts.InactiveBy.Noun()))
 
 	b.WriteString("\n")
 
@@ -913,8 +1589,26 @@ func (m Model) viewFilter() string {
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("  %s Reset to defaults\n", helpKeyStyle.Render("r")))
 
+	b.WriteString("\n")
+	b.WriteString(m.viewPresets())
+
+	if m.message != "" {
+		b.WriteString("\n")
+		if m.messageIsError {
+			b.WriteString(dangerStyle.Render(m.message))
+		} else {
+			b.WriteString(successStyle.Render(m.message))
+		}
+	}
+
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("Press esc or f to return"))
+	b.WriteString(helpStyle.Render(strings.Join([]string{
+		helpKeyStyle.Render("j/k") + " pick preset",
+		helpKeyStyle.Render("enter") + " load",
+		helpKeyStyle.Render("w") + " save current",
+		helpKeyStyle.Render("x") + " delete",
+		helpKeyStyle.Render("esc/f") + " back",
+	}, "  ")))
 
 	return appStyle.Render(b.String())
 }
@@ -940,16 +1634,31 @@ func (m Model) viewDetail() string {
 		label string
 		value string
 	}{
+		{"Owner", r.OwnerLogin()},
 		{"Visibility", r.VisibilityString()},
 		{"Status", r.StatusString()},
 		{"Language", r.PrimaryLanguage},
+		{"License", r.LicenseString()},
+		{"Branch", r.DefaultBranch},
+		{"Topics", strings.Join(r.Topics, ", ")},
+		{"Homepage", r.HomepageURL},
 		{"Stars", fmt.Sprintf("%d", r.StargazerCount)},
 		{"Forks", fmt.Sprintf("%d", r.ForkCount)},
 		{"Open Issues", fmt.Sprintf("%d", r.OpenIssuesCount)},
 		{"Size", r.SizeString()},
 		{"Created", r.CreatedAt.Format("Jan 02, 2006")},
 		{"Last Updated", r.UpdatedAt.Format("Jan 02, 2006")},
-		{"Last Push", fmt.Sprintf("%s (%d days ago)", r.PushedAt.Format("Jan 02, 2006"), r.DaysSinceUpdate())},
+		// The same signal and day count as the list column
+		{"Last Activity", fmt.Sprintf("%s (%s)", formatDate(r.LastActivity(m.filterOpts.InactiveBy)), m.filterOpts.InactiveBy.Name())},
+		{"Last Push", formatDate(r.PushedAt)},
+		{"Last Commit", formatDate(r.LastCommitAt)},
+		{"Last Issue", formatDate(r.LastIssueAt)},
+		{"Last PR", formatDate(r.LastPRAt)},
+		{"Last Release", formatDate(r.LastReleaseAt)},
+		{"Open PRs", fmt.Sprintf("%d", r.OpenPRCount)},
+		{"Archive Score", scoreString(r.ArchiveScore())},
+		{"Protected", m.protectedString(r)},
+		{"Contributors", m.contributorsString(r)},
 	}
 
 	for _, item := range info {
@@ -959,6 +1668,16 @@ func (m Model) viewDetail() string {
 		}
 	}
 
+	if v, ok := m.violations[r.FullName]; ok {
+		b.WriteString("\n")
+		b.WriteString(dangerStyle.Render(fmt.Sprintf("  Policy rule %q: %s", v.Rule, v.Action)))
+		b.WriteString("\n")
+		for _, reason := range v.Reasons {
+			b.WriteString(mutedStyle.Render("    - " + reason))
+			b.WriteString("\n")
+		}
+	}
+
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("  URL: %s\n", mutedStyle.Render(r.URL)))
 	b.WriteString(fmt.Sprintf("  SSH: %s\n", mutedStyle.Render(r.SSHURL)))
@@ -969,6 +1688,8 @@ func (m Model) viewDetail() string {
 	b.WriteString(helpKeyStyle.Render("o") + " Open in browser  ")
 	if !r.IsArchived {
 		b.WriteString(helpKeyStyle.Render("a") + " Archive  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("U") + " Unarchive  ")
 	}
 	b.WriteString(helpKeyStyle.Render("esc") + " Back")
 
@@ -982,10 +1703,10 @@ func (m Model) viewConfirmArchive() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	// List repos to be archived
+	// List repos to be archived; protected ones are skipped
 	count := 0
 	for _, r := range m.repos {
-		if r.Selected && !r.IsArchived {
+		if r.Selected && !r.IsArchived && m.protectedReason(r) == "" {
 			count++
 			if count <= 5 {
 				b.WriteString(fmt.Sprintf("  • %s\n", r.FullName))
@@ -998,9 +1719,70 @@ func (m Model) viewConfirmArchive() string {
 
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("Archive %d %s?\n", count, pluralize(count, "repository", "repositories")))
+	if protected := m.selectedProtected(); protected > 0 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("%d protected %s skipped.", protected, pluralize(protected, "repository is", "repositories are"))) + "\n")
+	}
 	b.WriteString("Archived repos are read-only but can be unarchived later.\n\n")
+	b.WriteString(m.viewNoticeOption() + "\n")
+
+	if m.noticeInput.Focused() {
+		b.WriteString(helpKeyStyle.Render("enter") + " Done editing")
+		return appStyle.Render(dialogStyle.Render(b.String()))
+	}
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will be archived.\n\n"))
+		b.WriteString(helpKeyStyle.Render("y") + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("y") + " Yes, archive  ")
+	}
+	b.WriteString(helpKeyStyle.Render("n") + " No, cancel  ")
+	b.WriteString(helpKeyStyle.Render("m") + " Toggle notice")
+	if m.archiveNotice {
+		b.WriteString("  " + helpKeyStyle.Render("M") + " Moved to")
+	}
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
 
-	b.WriteString(helpKeyStyle.Render("y") + " Yes, archive  ")
+func (m Model) viewConfirmUnarchive() string {
+	var b strings.Builder
+
+	title := dialogTitleStyle.Render("↺ Confirm Unarchive")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	// List repos to be unarchived; selected repos that aren't archived are skipped
+	count, skipped := 0, 0
+	for _, r := range m.repos {
+		if !r.Selected {
+			continue
+		}
+		if !r.IsArchived {
+			skipped++
+			continue
+		}
+		count++
+		if count <= 5 {
+			b.WriteString(fmt.Sprintf("  • %s\n", r.FullName))
+		}
+	}
+	if count > 5 {
+		b.WriteString(fmt.Sprintf("  ... and %d more\n", count-5))
+	}
+	if skipped > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  (%d selected %s not archived)\n", skipped, pluralize(skipped, "repo is", "repos are"))))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Unarchive %d %s?\n", count, pluralize(count, "repository", "repositories")))
+	b.WriteString("They become writable again.\n\n")
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will be unarchived.\n\n"))
+		b.WriteString(helpKeyStyle.Render("y") + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("y") + " Yes, unarchive  ")
+	}
 	b.WriteString(helpKeyStyle.Render("n") + " No, cancel")
 
 	return appStyle.Render(dialogStyle.Render(b.String()))
@@ -1013,30 +1795,134 @@ func (m Model) viewConfirmDelete() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	// List repos to be deleted
-	count := 0
-	for _, r := range m.repos {
-		if r.Selected {
-			count++
-			if count <= 5 {
-				b.WriteString(fmt.Sprintf("  • %s\n", r.FullName))
-			}
+	if len(m.backupOrder) > 0 {
+		b.WriteString(m.viewBackupProgress())
+		return appStyle.Render(dialogStyle.Render(b.String()))
+	}
+
+	// List repos to be deleted, those above the delete limits first so they aren't hidden
+	targets := m.deleteTargets()
+	sort.SliceStable(targets, func(i, j int) bool {
+		return len(m.config.Delete.Blockers(targets[i])) > len(m.config.Delete.Blockers(targets[j]))
+	})
+	count := len(targets)
+	for i, r := range targets {
+		if i == 5 {
+			break
+		}
+		line := "  • " + r.FullName
+		if m.backupEnabled {
+			line += "  " + m.backupStatusLine(r.FullName)
 		}
+		if blockers := m.config.Delete.Blockers(r); len(blockers) > 0 {
+			line += "  " + warningStyle.Render("⚠ "+strings.Join(blockers, ", "))
+		}
+		b.WriteString(line + "\n")
 	}
 	if count > 5 {
 		b.WriteString(fmt.Sprintf("  ... and %d more\n", count-5))
 	}
 
+	if m.dependentsLoading {
+		b.WriteString(mutedStyle.Render("  Counting dependents...") + "\n")
+	} else if unknown := m.dependentsUnknown(); unknown > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  Dependents of %d unknown; the dependents limit doesn't apply to %s", unknown, pluralize(unknown, "it", "them"))) + "\n")
+	}
+
 	b.WriteString("\n")
 	b.WriteString(dangerStyle.Render(fmt.Sprintf("PERMANENTLY DELETE %d %s?\n", count, pluralize(count, "repository", "repositories"))))
+	if protected := m.selectedProtected(); protected > 0 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("%d protected %s skipped.", protected, pluralize(protected, "repository is", "repositories are"))) + "\n")
+	}
 	b.WriteString(dangerStyle.Render("This action CANNOT be undone!\n\n"))
 
-	b.WriteString(helpKeyStyle.Render("y") + " Yes, DELETE  ")
-	b.WriteString(helpKeyStyle.Render("n") + " No, cancel")
+	yKey, bKey, oKey, nKey := m.deleteKeys()
+	if m.typedDelete() {
+		b.WriteString(fmt.Sprintf("Type %s to confirm:\n", repoNameStyle.Render(m.deletePhrase())))
+		b.WriteString(filterInputStyle.Render(m.deleteInput.View()))
+		b.WriteString("\n")
+	}
+	if m.deleteErr != "" {
+		b.WriteString(dangerStyle.Render(m.deleteErr))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will be deleted.\n\n"))
+		b.WriteString(helpKeyStyle.Render(yKey) + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render(yKey) + " Yes, DELETE  ")
+	}
+	b.WriteString(helpKeyStyle.Render(nKey) + " No, cancel\n")
+	backupState := "off"
+	if m.backupEnabled {
+		backupState = "on, repos whose backup fails are kept"
+	}
+	b.WriteString(helpKeyStyle.Render(bKey) + " Backup first: " + backupState)
+	if blocked := m.deleteBlocked(); blocked > 0 {
+		overrideState := "off"
+		if m.deleteOverride {
+			overrideState = "on"
+		}
+		b.WriteString("\n" + helpKeyStyle.Render(oKey) + fmt.Sprintf(" Override delete limits for %d %s: %s", blocked, pluralize(blocked, "repo", "repos"), overrideState))
+	}
 
 	return appStyle.Render(dialogStyle.Render(b.String()))
 }
 
+func (m Model) viewPlan() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Dry run: %s %d %s ", m.plan.Action, len(m.plan.Items), pluralize(len(m.plan.Items), "repository", "repositories"))))
+	b.WriteString("\n")
+	if len(m.plan.Filters) > 0 {
+		b.WriteString(statsStyle.Render("Filters: " + strings.Join(m.plan.Filters, ", ")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Each item takes its name line plus one line per reason
+	budget := m.visibleRows()
+	if budget < 5 {
+		budget = 5
+	}
+	lines := 0
+	shown := m.planOffset
+	for i := m.planOffset; i < len(m.plan.Items); i++ {
+		item := m.plan.Items[i]
+		if lines > 0 && lines+1+len(item.Reasons) > budget {
+			break
+		}
+		b.WriteString("  " + repoNameStyle.Render(item.Repo) + "\n")
+		for _, reason := range item.Reasons {
+			b.WriteString(mutedStyle.Render("      - "+reason) + "\n")
+		}
+		lines += 1 + len(item.Reasons)
+		shown = i + 1
+	}
+	if remaining := len(m.plan.Items) - shown; remaining > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  ... and %d more (j/k to scroll)\n", remaining)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(warningStyle.Render("No changes were made."))
+
+	if m.message != "" {
+		b.WriteString("\n")
+		if m.messageIsError {
+			b.WriteString(dangerStyle.Render(m.message))
+		} else {
+			b.WriteString(successStyle.Render(m.message))
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(helpKeyStyle.Render("w") + " save plan  " + helpKeyStyle.Render("j/k") + " scroll  " + helpKeyStyle.Render("esc") + " back"))
+
+	return appStyle.Render(b.String())
+}
+
 func (m Model) viewHelp() string {
 	var b strings.Builder
 
@@ -1061,8 +1947,8 @@ func (m Model) viewHelp() string {
 		{
 			"Search & Filter",
 			[]struct{ key, desc string }{
-				{"/", "Search repositories"},
-				{"f", "Open filter panel"},
+				{"/", "Search or query (lang:go stars:<5 pushed:<2023-01-01 -is:fork)"},
+				{"f", "Open filter panel and presets (w save, enter load, x delete)"},
 				{"s", "Cycle sort field"},
 				{"S", "Toggle sort direction"},
 				{"1-4", "Toggle filter options"},
@@ -1073,6 +1959,7 @@ func (m Model) viewHelp() string {
 			[]struct{ key, desc string }{
 				{"Space/x", "Toggle selection"},
 				{"A", "Select all visible"},
+				{"P", "Select policy violations"},
 				{"D", "Deselect all"},
 			},
 		},
@@ -1080,9 +1967,28 @@ func (m Model) viewHelp() string {
 			"Actions",
 			[]struct{ key, desc string }{
 				{"a", "Archive selected"},
+				{"U", "Unarchive selected"},
+				{"u", "Undo the last archive batch"},
+				{"V", "Change visibility of selected (public/private/internal)"},
+				{"T", "Transfer selected to another owner"},
+				{"t", "Add or remove topics on selected"},
+				{"e", "Edit description/homepage, or prefix selected descriptions"},
 				{"d", "Delete selected (dangerous!)"},
 				{"o", "Open in browser"},
 				{"r", "Reload repositories"},
+				{"O", "Switch owner/organization"},
+				{"p", "Toggle dry run (plan only)"},
+				{"L", "Show audit log"},
+				{"R", "Show archive recommendations"},
+			},
+		},
+		{
+			"Progress view",
+			[]struct{ key, desc string }{
+				{"r", "Retry failed repos"},
+				{"c", "Copy errors to clipboard"},
+				{"u", "Undo the archive batch just finished"},
+				{"enter", "Back to list"},
 			},
 		},
 		{
@@ -1098,7 +2004,11 @@ func (m Model) viewHelp() string {
 		b.WriteString(repoNameStyle.Render(section.name))
 		b.WriteString("\n")
 		for _, bind := range section.binds {
-			key := helpKeyStyle.Render(fmt.Sprintf("%-12s", bind.key))
+			label := bind.key
+			if section.name != "Progress view" {
+				label = m.keys.label(bind.key)
+			}
+			key := helpKeyStyle.Render(fmt.Sprintf("%-12s", label))
 			b.WriteString(fmt.Sprintf("  %s %s\n", key, bind.desc))
 		}
 		b.WriteString("\n")
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+func newNoticeInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "New location (optional), e.g. acme/new-repo"
+	ti.CharLimit = 200
+	ti.Width = 40
+	return ti
+}
+
+// noticeArchive returns the client call that commits the README notice and
+// then archives. A repo whose notice fails is not archived. Without a client
+// every archive succeeds without doing anything.
+func (m *Model) noticeArchive() func(fullName string) error {
+	if m.client == nil {
+		return func(string) error { return nil }
+	}
+	client := m.client
+	notice := m.config.ArchiveNotice
+	movedTo := strings.TrimSpace(m.noticeInput.Value())
+	return func(fullName string) error {
+		text, err := notice.Render(fullName, movedTo)
+		if err != nil {
+			return fmt.Errorf("archive notice: %w", err)
+		}
+		if err := client.PrependReadme(fullName, text, notice.CommitMessage); err != nil {
+			return fmt.Errorf("not archived: %w", err)
+		}
+		return client.ArchiveRepo(fullName)
+	}
+}
+
+// noticeDetails records the README notice in the audit log of an archive
+func (m *Model) noticeDetails() func(r repo.Repo) map[string]string {
+	d := map[string]string{"notice": "README.md"}
+	if movedTo := strings.TrimSpace(m.noticeInput.Value()); movedTo != "" {
+		d["moved_to"] = movedTo
+	}
+	return func(repo.Repo) map[string]string { return d }
+}
+
+// handleNoticeInputKeys edits the notice's new location in the archive dialog
+func (m Model) handleNoticeInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc", "tab":
+		m.noticeInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.noticeInput, cmd = m.noticeInput.Update(msg)
+	return m, cmd
+}
+
+// viewNoticeOption describes the README notice in the archive dialog
+func (m Model) viewNoticeOption() string {
+	var b strings.Builder
+	if !m.archiveNotice {
+		b.WriteString(mutedStyle.Render("README notice: off") + "\n")
+		return b.String()
+	}
+	b.WriteString("README notice: " + successStyle.Render("on") + mutedStyle.Render(" (committed to README.md first)") + "\n")
+	b.WriteString(mutedStyle.Render("Moved to") + " " + m.noticeInput.View() + "\n")
+	preview, err := m.config.ArchiveNotice.Render("owner/repo", strings.TrimSpace(m.noticeInput.Value()))
+	if err != nil {
+		b.WriteString(dangerStyle.Render(err.Error()) + "\n")
+		return b.String()
+	}
+	for _, line := range strings.Split(strings.TrimRight(preview, "\n"), "\n") {
+		b.WriteString(mutedStyle.Render("  │ "+truncate(line, 60)) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/config"
+)
+
+// newPresetInput creates the text input used to name a preset
+func newPresetInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Preset name"
+	ti.CharLimit = 50
+	ti.Width = 30
+	return ti
+}
+
+// handlePresetKeys handles preset keys in the filter view. It reports
+// whether the key was used.
+func (m *Model) handlePresetKeys(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.presetInput.Focused() {
+		switch msg.String() {
+		case "enter":
+			m.presetInput.Blur()
+			m.savePreset(strings.TrimSpace(m.presetInput.Value()))
+		case "esc":
+			m.presetInput.Blur()
+		default:
+			var cmd tea.Cmd
+			m.presetInput, cmd = m.presetInput.Update(msg)
+			return true, cmd
+		}
+		return true, nil
+	}
+
+	switch msg.String() {
+	case "w":
+		m.presetInput.SetValue(m.presetName)
+		m.presetInput.CursorEnd()
+		m.presetInput.Focus()
+		return true, textinput.Blink
+	case "up", "k":
+		if m.presetCursor > 0 {
+			m.presetCursor--
+		}
+	case "down", "j":
+		if m.presetCursor < len(m.config.Presets)-1 {
+			m.presetCursor++
+		}
+	case "enter":
+		if m.presetCursor < len(m.config.Presets) {
+			m.loadPreset(m.config.Presets[m.presetCursor])
+		}
+	case "x":
+		if m.presetCursor < len(m.config.Presets) {
+			name := m.config.Presets[m.presetCursor].Name
+			if err := m.config.DeletePreset(name); err != nil {
+				m.message = fmt.Sprintf("Failed to delete preset: %v", err)
+				m.messageIsError = true
+				break
+			}
+			if m.presetCursor >= len(m.config.Presets) && m.presetCursor > 0 {
+				m.presetCursor--
+			}
+			if strings.EqualFold(m.presetName, name) {
+				m.presetName = ""
+			}
+			m.message = fmt.Sprintf("Deleted preset %q", name)
+			m.messageIsError = false
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// savePreset stores the current filters under name
+func (m *Model) savePreset(name string) {
+	if name == "" {
+		return
+	}
+	p := config.Preset{Name: name, Filter: config.FromOptions(m.filterOpts)}
+	if err := m.config.SetPreset(p); err != nil {
+		m.message = fmt.Sprintf("Failed to save preset: %v", err)
+		m.messageIsError = true
+		return
+	}
+	for i, saved := range m.config.Presets {
+		if strings.EqualFold(saved.Name, name) {
+			m.presetCursor = i
+		}
+	}
+	m.presetName = name
+	m.message = fmt.Sprintf("Saved preset %q", name)
+	m.messageIsError = false
+}
+
+// loadPreset replaces the filters, sort and search with a preset's
+func (m *Model) loadPreset(p config.Preset) {
+	opts, err := p.Options()
+	if err != nil {
+		m.message = fmt.Sprintf("Invalid preset %q: %v", p.Name, err)
+		m.messageIsError = true
+		return
+	}
+	m.filterOpts = opts
+	m.searchInput.SetValue(opts.SearchQuery)
+	m.queryErr = nil
+	m.presetName = p.Name
+	m.cursor, m.offset = 0, 0
+	m.applyFilters()
+	m.message = fmt.Sprintf("Loaded preset %q", p.Name)
+	m.messageIsError = false
+}
+
+// viewPresets renders the preset picker section of the filter view
+func (m Model) viewPresets() string {
+	var b strings.Builder
+	b.WriteString("  Presets")
+	if path, err := config.Path(); err == nil {
+		b.WriteString(" " + mutedStyle.Render(path))
+	}
+	b.WriteString("\n")
+
+	if len(m.config.Presets) == 0 {
+		b.WriteString(mutedStyle.Render("    No saved presets") + "\n")
+	}
+	for i, p := range m.config.Presets {
+		cursor := "  "
+		if i == m.presetCursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("  %s%s", cursor, p.Name)
+		if strings.EqualFold(p.Name, m.presetName) {
+			line += mutedStyle.Render(" (active)")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if m.presetInput.Focused() {
+		b.WriteString("\n  Save as: " + filterInputStyle.Render(m.presetInput.View()) + "\n")
+	}
+	return b.String()
+}
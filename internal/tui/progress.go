@@ -0,0 +1,472 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/audit"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/repo"
+	"github.com/user/gh-repo-review/internal/worker"
+)
+
+// jobStatus is the state of one repo in a bulk job
+type jobStatus int
+
+const (
+	jobPending jobStatus = iota
+	jobRunning
+	jobSucceeded
+	jobFailed
+)
+
+// jobItem is one repo in a bulk job
+type jobItem struct {
+	repo     repo.Repo
+	status   jobStatus
+	err      error
+	note     string // retry progress while running
+	started  time.Time
+	finished time.Time
+}
+
+// bulkJob is an archive, unarchive or delete over a set of repos
+type bulkJob struct {
+	id       int
+	action   string
+	items    []jobItem
+	started  time.Time
+	finished time.Time
+	offset   int
+	// details returns the action's parameters for the audit log; may be nil
+	details func(r repo.Repo) map[string]string
+	// note explains repos left out of the job, e.g. after failed backups
+	note string
+}
+
+// jobEventMsg carries one worker pool event back to Update along with the
+// command that waits for the next one
+type jobEventMsg struct {
+	job   *bulkJob
+	event worker.Event
+	next  tea.Cmd
+}
+
+type jobTickMsg struct{ jobID int }
+
+// actionVerbs holds the progressive and past forms used in the progress view
+var actionVerbs = map[string][2]string{
+	"archive":   {"Archiving", "Archived"},
+	"unarchive": {"Unarchiving", "Unarchived"},
+	"delete":    {"Deleting", "Deleted"},
+
+	"make-public":   {"Making public", "Made public"},
+	"make-private":  {"Making private", "Made private"},
+	"make-internal": {"Making internal", "Made internal"},
+	"transfer":      {"Transferring", "Transferred"},
+	"topics":        {"Tagging", "Tagged"},
+	"edit":          {"Updating", "Updated"},
+}
+
+// startJob runs action over the repos matching include and shows progress
+func (m *Model) startJob(action string, include func(repo.Repo) bool) tea.Cmd {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if include(r) {
+			targets = append(targets, r)
+		}
+	}
+	return m.runJob(action, targets)
+}
+
+// runJob queues targets on the worker pool and shows their progress
+func (m *Model) runJob(action string, targets []repo.Repo) tea.Cmd {
+	m.jobSeq++
+	job := &bulkJob{id: m.jobSeq, action: action, started: time.Now(), details: m.jobDetails(action)}
+	for _, r := range targets {
+		job.items = append(job.items, jobItem{repo: r, status: jobPending})
+	}
+	m.job = job
+	m.view = ViewProgress
+
+	if len(targets) == 0 {
+		job.finished = time.Now()
+		return nil
+	}
+
+	run := m.jobFunc(action)
+	tasks := make([]worker.Task, len(targets))
+	for i, r := range targets {
+		name := r.FullName
+		tasks[i] = func() error { return run(name) }
+	}
+	events := m.pool.Run(tasks)
+
+	return tea.Batch(listenJob(job, events, targets, m.auditLogger()), jobTick(job.id))
+}
+
+// jobFunc returns the client call for action, including the actions whose
+// parameters come from a dialog
+func (m *Model) jobFunc(action string) func(fullName string) error {
+	switch action {
+	case "archive":
+		if m.archiveNotice {
+			return m.noticeArchive()
+		}
+	case "transfer":
+		return m.transfer.run(m.client)
+	case "topics":
+		return m.topics.run(m.client)
+	case "edit":
+		return m.metadata.run(m.client)
+	}
+	return actionFunc(m.client, action)
+}
+
+// jobDetails returns the parameters of the dialog-driven actions for the
+// audit log, or nil when the action has none
+func (m *Model) jobDetails(action string) func(r repo.Repo) map[string]string {
+	switch action {
+	case "archive":
+		if m.archiveNotice {
+			return m.noticeDetails()
+		}
+	case "transfer":
+		return m.transfer.details
+	case "topics":
+		return m.topics.details
+	case "edit":
+		return m.metadata.details
+	}
+	return nil
+}
+
+// actionFunc returns the client call for action. Without a client every
+// action succeeds without doing anything.
+func actionFunc(client *gh.Client, action string) func(fullName string) error {
+	if client == nil {
+		return func(string) error { return nil }
+	}
+	switch action {
+	case "archive":
+		return client.ArchiveRepo
+	case "unarchive":
+		return client.UnarchiveRepo
+	case "delete":
+		return client.DeleteRepo
+	}
+	if visibility, ok := visibilityOf(action); ok {
+		return func(fullName string) error { return client.SetVisibility(fullName, visibility) }
+	}
+	return func(string) error { return fmt.Errorf("unknown action %q", action) }
+}
+
+// listenJob waits for the next pool event, recording final results in the
+// audit log. It returns nil once the pool is done.
+func listenJob(job *bulkJob, events <-chan worker.Event, targets []repo.Repo, logger audit.Logger) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		if ev.State == worker.Finished {
+			r := targets[ev.Index]
+			var details map[string]string
+			if job.details != nil {
+				details = job.details(r)
+			}
+			_ = logger.Record(job.action, r.FullName, audit.StateOf(r), details, ev.Err)
+		}
+		return jobEventMsg{job: job, event: ev, next: listenJob(job, events, targets, logger)}
+	}
+}
+
+func jobTick(jobID int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return jobTickMsg{jobID: jobID}
+	})
+}
+
+// handleJobEvent updates the job from one pool event and applies results to
+// the local repo list
+func (m *Model) handleJobEvent(msg jobEventMsg) {
+	job := msg.job
+	if msg.event.Index >= len(job.items) {
+		return
+	}
+
+	item := &job.items[msg.event.Index]
+	switch msg.event.State {
+	case worker.Started:
+		item.status = jobRunning
+		item.started = time.Now()
+		return
+	case worker.Retrying:
+		item.note = fmt.Sprintf("attempt %d failed, retrying in %s", msg.event.Attempt, msg.event.Wait.Round(time.Second))
+		return
+	}
+
+	item.finished = time.Now()
+	item.note = ""
+	if msg.event.Err != nil {
+		item.status = jobFailed
+		item.err = msg.event.Err
+	} else {
+		item.status = jobSucceeded
+		m.applyActionResult(job.action, item.repo.FullName)
+	}
+
+	if !job.done() {
+		return
+	}
+	job.finished = time.Now()
+	m.recordUndo(job)
+
+	// A job the view has moved on from still updates the repo list, but
+	// not the status line
+	if job != m.job {
+		return
+	}
+	succeeded, failed := job.counts()
+	verbs := actionVerbs[job.action]
+	m.message = fmt.Sprintf("%s %d %s", verbs[1], succeeded, pluralize(succeeded, "repository", "repositories"))
+	m.messageIsError = failed > 0
+	if failed > 0 {
+		m.message += fmt.Sprintf(", %d failed", failed)
+	}
+	if job.action == "archive" && succeeded > 0 {
+		m.message += fmt.Sprintf(" (%s to undo)", m.keys.label("u"))
+	}
+	// A single successful action needs no summary screen
+	if len(job.items) == 1 && failed == 0 && m.view == ViewProgress {
+		m.view = ViewList
+	}
+}
+
+// applyActionResult reflects a successful action in m.repos
+func (m *Model) applyActionResult(action, fullName string) {
+	for i := range m.repos {
+		if m.repos[i].FullName != fullName {
+			continue
+		}
+		switch action {
+		case "archive":
+			m.repos[i].IsArchived = true
+			m.repos[i].Selected = false
+		case "unarchive":
+			m.repos[i].IsArchived = false
+			m.repos[i].Selected = false
+		case "delete", "transfer":
+			// A transferred repo belongs to another owner now
+			m.repos = append(m.repos[:i], m.repos[i+1:]...)
+		case "topics":
+			m.repos[i].Topics = repo.EditTopics(m.repos[i].Topics, m.topics.add, m.topics.remove)
+			m.repos[i].Selected = false
+		case "edit":
+			// The selection stays so a prefixed batch can be archived next
+			m.metadata.apply(&m.repos[i])
+		default:
+			if visibility, ok := visibilityOf(action); ok {
+				m.repos[i].Visibility = strings.ToUpper(visibility)
+				m.repos[i].IsPrivate = visibility != "public"
+				m.repos[i].Selected = false
+			}
+		}
+		break
+	}
+	m.updateSelectedCount()
+	m.applyFilters()
+}
+
+// done reports whether every item has finished
+func (j *bulkJob) done() bool {
+	for _, item := range j.items {
+		if item.status == jobPending || item.status == jobRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// counts returns the number of succeeded and failed items
+func (j *bulkJob) counts() (succeeded, failed int) {
+	for _, item := range j.items {
+		switch item.status {
+		case jobSucceeded:
+			succeeded++
+		case jobFailed:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+
+// elapsed returns the running time of the job
+func (j *bulkJob) elapsed() time.Duration {
+	end := j.finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(j.started).Round(time.Second)
+}
+
+// errorReport lists every failure, one per line
+func (j *bulkJob) errorReport() string {
+	var b strings.Builder
+	for _, item := range j.items {
+		if item.status == jobFailed && item.err != nil {
+			fmt.Fprintf(&b, "%s: %s\n", item.repo.FullName, strings.TrimSpace(item.err.Error()))
+		}
+	}
+	return b.String()
+}
+
+// handleProgressKeys handles keys in the bulk progress view
+func (m Model) handleProgressKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.job == nil {
+		m.view = ViewList
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.job.offset > 0 {
+			m.job.offset--
+		}
+	case "down", "j":
+		if m.job.offset < len(m.job.items)-1 {
+			m.job.offset++
+		}
+	}
+
+	if !m.job.done() {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "enter", "esc", "q":
+		m.view = ViewList
+	case "u":
+		return m, m.undoArchive()
+	case "r":
+		var failed []repo.Repo
+		for _, item := range m.job.items {
+			if item.status == jobFailed {
+				failed = append(failed, item.repo)
+			}
+		}
+		if len(failed) > 0 {
+			return m, m.runJob(m.job.action, failed)
+		}
+	case "c":
+		report := m.job.errorReport()
+		if report == "" {
+			m.message = "No errors to copy"
+			m.messageIsError = false
+		} else if err := clipboard.WriteAll(report); err != nil {
+			m.message = fmt.Sprintf("Failed to copy errors: %v", err)
+			m.messageIsError = true
+		} else {
+			m.message = "Errors copied to clipboard"
+			m.messageIsError = false
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewProgress() string {
+	if m.job == nil {
+		return appStyle.Render("No operation in progress")
+	}
+	job := m.job
+	var b strings.Builder
+
+	verbs := actionVerbs[job.action]
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" %s %d %s ", verbs[0], len(job.items), pluralize(len(job.items), "repository", "repositories"))))
+	b.WriteString("\n\n")
+
+	succeeded, failed := job.counts()
+	finished := succeeded + failed
+	percent := 0.0
+	if len(job.items) > 0 {
+		percent = float64(finished) / float64(len(job.items))
+	}
+	b.WriteString("  " + m.progressBar.ViewAs(percent))
+	b.WriteString("\n")
+	b.WriteString(statsStyle.Render(fmt.Sprintf("  %d/%d done · %d succeeded · %d failed · %s elapsed · %d workers",
+		finished, len(job.items), succeeded, failed, job.elapsed(), m.pool.Workers())))
+	b.WriteString("\n\n")
+
+	visible := m.visibleRows() - 4
+	if visible < 3 {
+		visible = 3
+	}
+	end := job.offset + visible
+	if end > len(job.items) {
+		end = len(job.items)
+	}
+	for _, item := range job.items[job.offset:end] {
+		var status string
+		switch item.status {
+		case jobPending:
+			status = mutedStyle.Render("· pending  ")
+		case jobRunning:
+			status = warningStyle.Render("… running  ")
+		case jobSucceeded:
+			status = successStyle.Render("✓ " + strings.ToLower(verbs[1]))
+		case jobFailed:
+			status = dangerStyle.Render("✗ failed   ")
+		}
+		line := fmt.Sprintf("  %s %s", status, item.repo.FullName)
+		if item.err != nil {
+			line += " " + mutedStyle.Render(truncate(strings.TrimSpace(item.err.Error()), 60))
+		} else if item.note != "" {
+			line += " " + warningStyle.Render(item.note)
+		}
+		b.WriteString(line + "\n")
+	}
+	if remaining := len(job.items) - end; remaining > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  ... and %d more (j/k to scroll)\n", remaining)))
+	}
+
+	b.WriteString("\n")
+	if job.done() {
+		summary := fmt.Sprintf("Finished in %s: %d succeeded, %d failed", job.elapsed(), succeeded, failed)
+		if failed > 0 {
+			b.WriteString(dangerStyle.Render(summary))
+		} else {
+			b.WriteString(successStyle.Render(summary))
+		}
+		if m.message != "" && m.message != summary {
+			b.WriteString("\n" + mutedStyle.Render(m.message))
+		}
+		if job.note != "" {
+			b.WriteString("\n" + warningStyle.Render(job.note))
+		}
+		b.WriteString("\n\n")
+		helpItems := []string{helpKeyStyle.Render("enter") + " back"}
+		if job.action == "archive" && succeeded > 0 {
+			helpItems = append(helpItems, helpKeyStyle.Render("u")+" undo")
+		}
+		if failed > 0 {
+			helpItems = append(helpItems,
+				helpKeyStyle.Render("r")+" retry failed",
+				helpKeyStyle.Render("c")+" copy errors")
+		}
+		helpItems = append(helpItems, helpKeyStyle.Render("j/k")+" scroll")
+		b.WriteString(helpStyle.Render(strings.Join(helpItems, "  ")))
+	} else {
+		b.WriteString(helpStyle.Render(helpKeyStyle.Render("j/k") + " scroll"))
+	}
+
+	return appStyle.Render(b.String())
+}
+
+// newProgressBar creates the bar used by the progress view
+func newProgressBar() progress.Model {
+	return progress.New(progress.WithSolidFill(string(primaryColor)), progress.WithWidth(40))
+}
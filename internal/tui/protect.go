@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// protectedReason explains why r is protected by the config, or returns ""
+func (m Model) protectedReason(r repo.Repo) string {
+	return m.config.Protect.Reason(r)
+}
+
+// selectedProtected counts selected repos that archive and delete will skip
+func (m Model) selectedProtected() int {
+	n := 0
+	for _, r := range m.repos {
+		if r.Selected && m.protectedReason(r) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// deselectProtected drops protected repos from the selection before an
+// archive or delete runs
+func (m *Model) deselectProtected() {
+	for i := range m.repos {
+		if m.repos[i].Selected && m.protectedReason(m.repos[i]) != "" {
+			m.repos[i].Selected = false
+		}
+	}
+	for i := range m.filteredRepos {
+		if m.filteredRepos[i].Selected && m.protectedReason(m.filteredRepos[i]) != "" {
+			m.filteredRepos[i].Selected = false
+		}
+	}
+	m.updateSelectedCount()
+}
+
+// refuseProtected reports whether r is protected, explaining so in the status line
+func (m *Model) refuseProtected(r repo.Repo) bool {
+	reason := m.protectedReason(r)
+	if reason == "" {
+		return false
+	}
+	m.message = fmt.Sprintf("%s is protected (%s)", r.FullName, reason)
+	m.messageIsError = true
+	return true
+}
+
+// protectedString is the detail view value for protection
+func (m Model) protectedString(r repo.Repo) string {
+	if reason := m.protectedReason(r); reason != "" {
+		return "Yes, " + reason
+	}
+	return "No"
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// openRecommendations ranks the visible unarchived, unprotected repos by
+// archive score
+func (m *Model) openRecommendations() {
+	var candidates []repo.Repo
+	for _, r := range m.filteredRepos {
+		if m.protectedReason(r) == "" {
+			candidates = append(candidates, r)
+		}
+	}
+	m.recommended = repo.Recommend(candidates, 0)
+	m.recommendCursor = 0
+	m.view = ViewRecommend
+}
+
+// handleRecommendKeys handles keys in the recommendations view
+func (m Model) handleRecommendKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "R":
+		m.view = ViewList
+	case "up", "k":
+		if m.recommendCursor > 0 {
+			m.recommendCursor--
+		}
+	case "down", "j":
+		if m.recommendCursor < len(m.recommended)-1 {
+			m.recommendCursor++
+		}
+	case " ", "x":
+		if len(m.recommended) > 0 {
+			m.toggleRecommended(m.recommendCursor)
+		}
+	case "a":
+		if len(m.recommended) == 0 {
+			return m, nil
+		}
+		if m.selectedCount == 0 {
+			m.toggleRecommended(m.recommendCursor)
+		}
+		m.view = ViewConfirmArchive
+	case "enter", "l":
+		if len(m.recommended) > 0 {
+			if idx := m.findFilteredIndex(m.recommended[m.recommendCursor].FullName); idx >= 0 {
+				m.cursor = idx
+				m.adjustOffset()
+				m.view = ViewDetail
+			}
+		}
+	}
+	return m, nil
+}
+
+// toggleRecommended flips the selection of a recommended repo everywhere it is listed
+func (m *Model) toggleRecommended(i int) {
+	r := &m.recommended[i]
+	r.Selected = !r.Selected
+	for j := range m.repos {
+		if m.repos[j].FullName == r.FullName {
+			m.repos[j].Selected = r.Selected
+		}
+	}
+	if idx := m.findFilteredIndex(r.FullName); idx >= 0 {
+		m.filteredRepos[idx].Selected = r.Selected
+	}
+	m.updateSelectedCount()
+}
+
+// dependentsUnknown counts the repos whose score leaves out dependents
+func dependentsUnknown(repos []repo.Repo) int {
+	unknown := 0
+	for _, r := range repos {
+		if r.Dependents < 0 {
+			unknown++
+		}
+	}
+	return unknown
+}
+
+func (m Model) viewRecommendations() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Archive recommendations | %d %s ",
+		len(m.recommended), pluralize(len(m.recommended), "candidate", "candidates"))))
+	b.WriteString("\n")
+	b.WriteString(statsStyle.Render("Scored 0-100 from inactivity, stars, forks, open work, size and fork status, less dependents"))
+	if unknown := dependentsUnknown(m.recommended); unknown > 0 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf(" · dependents not counted for %d", unknown)))
+	}
+	b.WriteString("\n\n")
+
+	if len(m.recommended) == 0 {
+		b.WriteString(mutedStyle.Render("  No unarchived repositories match the current filters."))
+		b.WriteString("\n")
+	}
+
+	// Each candidate takes two lines
+	visible := m.visibleRows() / 2
+	if visible < 1 {
+		visible = 5
+	}
+	start := 0
+	if m.recommendCursor >= visible {
+		start = m.recommendCursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.recommended) {
+		end = len(m.recommended)
+	}
+
+	for i := start; i < end; i++ {
+		r := m.recommended[i]
+		score := r.ArchiveScore()
+
+		cursor := "  "
+		if i == m.recommendCursor {
+			cursor = cursorStyle.Render("▸ ")
+		}
+		checkbox := uncheckedStyle.Render("○")
+		if r.Selected {
+			checkbox = checkboxStyle.Render("●")
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s %s\n", cursor, checkbox, scoreStyle(score.Total).Render(fmt.Sprintf("%3d", score.Total)), repoNameStyle.Render(r.FullName)))
+
+		var reasons []string
+		for _, f := range score.TopFactors(3) {
+			reasons = append(reasons, fmt.Sprintf("%s +%.0f", f.Detail, f.Points))
+		}
+		for _, f := range score.Penalties() {
+			reasons = append(reasons, fmt.Sprintf("%s %.0f", f.Detail, f.Points))
+		}
+		b.WriteString("        " + mutedStyle.Render(strings.Join(reasons, " · ")) + "\n")
+	}
+
+	b.WriteString("\n")
+	helpItems := []string{
+		helpKeyStyle.Render("j/k") + " move",
+		helpKeyStyle.Render("space") + " select",
+		helpKeyStyle.Render("a") + " archive",
+		helpKeyStyle.Render("enter") + " details",
+		helpKeyStyle.Render("esc") + " back",
+	}
+	if m.selectedCount > 0 {
+		helpItems = append(helpItems, successStyle.Render(fmt.Sprintf("%d selected", m.selectedCount)))
+	}
+	b.WriteString(helpStyle.Render(strings.Join(helpItems, "  ")))
+
+	return appStyle.Render(b.String())
+}
+
+// scoreStyle colors high scores as stronger archive candidates
+func scoreStyle(score int) lipgloss.Style {
+	switch {
+	case score >= 70:
+		return dangerStyle
+	case score >= 40:
+		return warningStyle
+	default:
+		return successStyle
+	}
+}
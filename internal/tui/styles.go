@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user/gh-repo-review/internal/config"
 )
 
 var (
@@ -13,135 +14,207 @@ var (
 	mutedColor     = lipgloss.Color("#6B7280") // Gray
 	bgColor        = lipgloss.Color("#1F2937") // Dark gray
 	fgColor        = lipgloss.Color("#F9FAFB") // Light gray
+)
+
+// Styles, built from the palette by buildStyles
+var (
+	appStyle          lipgloss.Style
+	titleStyle        lipgloss.Style
+	statusBarStyle    lipgloss.Style
+	listItemStyle     lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	cursorStyle       lipgloss.Style
+	repoNameStyle     lipgloss.Style
+	repoDescStyle     lipgloss.Style
+	privateTagStyle   lipgloss.Style
+	internalTagStyle  lipgloss.Style
+	publicTagStyle    lipgloss.Style
+	archivedTagStyle  lipgloss.Style
+	dryRunTagStyle    lipgloss.Style
+	forkTagStyle      lipgloss.Style
+	policyTagStyle    lipgloss.Style
+	protectedTagStyle lipgloss.Style
+	statsStyle        lipgloss.Style
+	starStyle         lipgloss.Style
+	forkStyle         lipgloss.Style
+	helpStyle         lipgloss.Style
+	helpKeyStyle      lipgloss.Style
+	helpDescStyle     lipgloss.Style
+	panelStyle        lipgloss.Style
+	activePanelStyle  lipgloss.Style
+	filterInputStyle  lipgloss.Style
+	dialogStyle       lipgloss.Style
+	dialogTitleStyle  lipgloss.Style
+	checkboxStyle     lipgloss.Style
+	uncheckedStyle    lipgloss.Style
+	mutedStyle        lipgloss.Style
+	dangerStyle       lipgloss.Style
+	successStyle      lipgloss.Style
+	warningStyle      lipgloss.Style
+)
 
+func init() {
+	buildStyles()
+}
+
+// buildStyles (re)creates every style from the current palette
+func buildStyles() {
 	// App styles
 	appStyle = lipgloss.NewStyle().
-			Padding(1, 2)
+		Padding(1, 2)
 
 	// Title bar
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(fgColor).
-			Background(primaryColor).
-			Padding(0, 2).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(fgColor).
+		Background(primaryColor).
+		Padding(0, 2).
+		MarginBottom(1)
 
 	// Status bar
 	statusBarStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			MarginTop(1)
+		Foreground(mutedColor).
+		MarginTop(1)
 
 	// List styles
 	listItemStyle = lipgloss.NewStyle().
-			PaddingLeft(2)
+		PaddingLeft(2)
 
 	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(primaryColor).
-				Bold(true).
-				PaddingLeft(2)
+		Foreground(primaryColor).
+		Bold(true).
+		PaddingLeft(2)
 
 	cursorStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+		Foreground(primaryColor).
+		Bold(true)
 
 	// Repo details
 	repoNameStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(fgColor)
+		Bold(true).
+		Foreground(fgColor)
 
 	repoDescStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			Italic(true)
+		Foreground(mutedColor).
+		Italic(true)
 
 	// Tags
 	privateTagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFF")).
-			Background(warningColor).
-			Padding(0, 1).
-			MarginLeft(1)
+		Foreground(lipgloss.Color("#FFF")).
+		Background(warningColor).
+		Padding(0, 1).
+		MarginLeft(1)
+
+	internalTagStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFF")).
+		Background(primaryColor).
+		Padding(0, 1).
+		MarginLeft(1)
 
 	publicTagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFF")).
-			Background(secondaryColor).
-			Padding(0, 1).
-			MarginLeft(1)
+		Foreground(lipgloss.Color("#FFF")).
+		Background(secondaryColor).
+		Padding(0, 1).
+		MarginLeft(1)
 
 	archivedTagStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFF")).
-				Background(mutedColor).
-				Padding(0, 1).
-				MarginLeft(1)
+		Foreground(lipgloss.Color("#FFF")).
+		Background(mutedColor).
+		Padding(0, 1).
+		MarginLeft(1)
+
+	dryRunTagStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#000")).
+		Background(warningColor).
+		Bold(true).
+		Padding(0, 1).
+		MarginLeft(1)
 
 	forkTagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFF")).
-			Background(lipgloss.Color("#3B82F6")).
-			Padding(0, 1).
-			MarginLeft(1)
+		Foreground(lipgloss.Color("#FFF")).
+		Background(lipgloss.Color("#3B82F6")).
+		Padding(0, 1).
+		MarginLeft(1)
+
+	policyTagStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFF")).
+		Background(dangerColor).
+		Padding(0, 1).
+		MarginLeft(1)
+
+	protectedTagStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#000")).
+		Background(secondaryColor).
+		Padding(0, 1).
+		MarginLeft(1)
 
 	// Stats
 	statsStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
+		Foreground(mutedColor)
 
 	starStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FCD34D"))
+		Foreground(lipgloss.Color("#FCD34D"))
 
 	forkStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#60A5FA"))
+		Foreground(lipgloss.Color("#60A5FA"))
 
 	// Help
 	helpStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			MarginTop(1)
+		Foreground(mutedColor).
+		MarginTop(1)
 
 	helpKeyStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+		Foreground(primaryColor).
+		Bold(true)
 
 	helpDescStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
+		Foreground(mutedColor)
 
 	// Panels
 	panelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(mutedColor).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(mutedColor).
+		Padding(1, 2)
 
 	activePanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(primaryColor).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2)
 
 	// Filter input
 	filterInputStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(primaryColor).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(0, 1)
 
 	// Confirmation dialog
 	dialogStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(dangerColor).
-			Padding(1, 2).
-			Width(50)
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(dangerColor).
+		Padding(1, 2).
+		Width(50)
 
 	dialogTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(dangerColor).
-				MarginBottom(1)
+		Bold(true).
+		Foreground(dangerColor).
+		MarginBottom(1)
 
 	// Selection indicator
 	checkboxStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true)
+		Foreground(secondaryColor).
+		Bold(true)
 
 	uncheckedStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
+		Foreground(mutedColor)
 
 	// Style helpers for inline rendering
 	mutedStyle = lipgloss.NewStyle().Foreground(mutedColor)
 	dangerStyle = lipgloss.NewStyle().Foreground(dangerColor)
 	successStyle = lipgloss.NewStyle().Foreground(secondaryColor)
+	warningStyle = lipgloss.NewStyle().Foreground(warningColor)
+}
 
+var (
 	// Language colors (common languages)
 	langColors = map[string]lipgloss.Color{
 		"Go":         lipgloss.Color("#00ADD8"),
@@ -163,6 +236,28 @@ var (
 	}
 )
 
+// applyTheme replaces the palette colors the theme sets and rebuilds the styles
+func applyTheme(t config.Theme) {
+	colors := []struct {
+		target *lipgloss.Color
+		value  string
+	}{
+		{&primaryColor, t.Primary},
+		{&secondaryColor, t.Secondary},
+		{&warningColor, t.Warning},
+		{&dangerColor, t.Danger},
+		{&mutedColor, t.Muted},
+		{&bgColor, t.Background},
+		{&fgColor, t.Foreground},
+	}
+	for _, c := range colors {
+		if c.value != "" {
+			*c.target = lipgloss.Color(c.value)
+		}
+	}
+	buildStyles()
+}
+
 // GetLangStyle returns a style for a language
 func GetLangStyle(lang string) lipgloss.Style {
 	color, ok := langColors[lang]
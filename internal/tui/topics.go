@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// topicEdit is the topics a topic job adds and removes
+type topicEdit struct {
+	add    []string
+	remove []string
+}
+
+// run returns the client call for the edit. Without a client every edit
+// succeeds without doing anything.
+func (t topicEdit) run(client *gh.Client) func(fullName string) error {
+	if client == nil {
+		return func(string) error { return nil }
+	}
+	return func(fullName string) error {
+		return client.EditTopics(fullName, t.add, t.remove)
+	}
+}
+
+// details records the added and removed topics, and the topics before the
+// edit, in the audit log
+func (t topicEdit) details(r repo.Repo) map[string]string {
+	return map[string]string{
+		"add":    strings.Join(t.add, ","),
+		"remove": strings.Join(t.remove, ","),
+		"before": strings.Join(r.Topics, ","),
+	}
+}
+
+// String describes the edit, e.g. "+keep -deprecated"
+func (t topicEdit) String() string {
+	var parts []string
+	for _, name := range t.add {
+		parts = append(parts, "+"+name)
+	}
+	for _, name := range t.remove {
+		parts = append(parts, "-"+name)
+	}
+	return strings.Join(parts, " ")
+}
+
+func newTopicInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "keep -deprecated"
+	ti.CharLimit = 200
+	ti.Width = 40
+	return ti
+}
+
+// topicTargets returns the selected repos a topic edit changes. Archived
+// repos are read-only.
+func (m Model) topicTargets() []repo.Repo {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if r.Selected && !r.IsArchived {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+// openTopics shows the topic editor for the selection, or the repo under
+// the cursor
+func (m *Model) openTopics() tea.Cmd {
+	if m.selectedCount == 0 {
+		idx := m.getActualIndex(m.cursor)
+		if idx < 0 {
+			return nil
+		}
+		if m.repos[idx].IsArchived {
+			m.message = m.repos[idx].FullName + " is archived; unarchive it to edit topics"
+			return nil
+		}
+		m.repos[idx].Selected = true
+		m.updateSelectedCount()
+	}
+	m.topicInput.SetValue("")
+	m.topicErr = ""
+	m.view = ViewTopics
+	m.topicInput.Focus()
+	return textinput.Blink
+}
+
+// topicSuggestions returns the topics used across the loaded repos that
+// complete the word being typed, most used first
+func (m Model) topicSuggestions() []string {
+	value := m.topicInput.Value()
+	words := strings.Fields(value)
+	prefix := ""
+	if len(words) > 0 && !strings.HasSuffix(value, " ") {
+		prefix = strings.TrimLeft(strings.ToLower(words[len(words)-1]), "+-")
+		words = words[:len(words)-1]
+	}
+	typed := make(map[string]bool)
+	for _, w := range words {
+		typed[strings.TrimLeft(strings.ToLower(w), "+-")] = true
+	}
+
+	counts := make(map[string]int)
+	for _, r := range m.repos {
+		for _, t := range r.Topics {
+			if strings.HasPrefix(t, prefix) && !typed[t] {
+				counts[t]++
+			}
+		}
+	}
+	suggestions := make([]string, 0, len(counts))
+	for t := range counts {
+		suggestions = append(suggestions, t)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if counts[suggestions[i]] != counts[suggestions[j]] {
+			return counts[suggestions[i]] > counts[suggestions[j]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	return suggestions
+}
+
+// completeTopic replaces the word being typed with the first suggestion
+func (m *Model) completeTopic() {
+	suggestions := m.topicSuggestions()
+	if len(suggestions) == 0 {
+		return
+	}
+	value := m.topicInput.Value()
+	start := strings.LastIndexAny(value, " ") + 1
+	word := value[start:]
+	sign := ""
+	if strings.HasPrefix(word, "-") || strings.HasPrefix(word, "+") {
+		sign = word[:1]
+	}
+	m.topicInput.SetValue(value[:start] + sign + suggestions[0] + " ")
+	m.topicInput.CursorEnd()
+}
+
+// parseTopics reads the editor: plain or + words add a topic, - words remove one
+func (m Model) parseTopics() (topicEdit, error) {
+	var edit topicEdit
+	for _, word := range strings.Fields(strings.ReplaceAll(m.topicInput.Value(), ",", " ")) {
+		word = strings.ToLower(word)
+		remove := strings.HasPrefix(word, "-")
+		name := strings.TrimLeft(word, "+-")
+		if !repo.ValidTopic(name) {
+			return edit, fmt.Errorf("%q is not a valid topic: use lowercase letters, numbers and hyphens", name)
+		}
+		if remove {
+			edit.remove = append(edit.remove, name)
+		} else {
+			edit.add = append(edit.add, name)
+		}
+	}
+	if len(edit.add) == 0 && len(edit.remove) == 0 {
+		return edit, fmt.Errorf("enter topics to add, or -topic to remove")
+	}
+	return edit, nil
+}
+
+// handleTopicKeys handles the topic editor
+func (m Model) handleTopicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		for i := range m.repos {
+			m.repos[i].Selected = false
+		}
+		m.selectedCount = 0
+		m.topicInput.Blur()
+		m.view = ViewList
+		return m, nil
+
+	case "tab":
+		m.completeTopic()
+		return m, nil
+
+	case "enter":
+		edit, err := m.parseTopics()
+		if err != nil {
+			m.topicErr = err.Error()
+			return m, nil
+		}
+		if len(m.topicTargets()) == 0 {
+			m.topicErr = "Every selected repository is archived"
+			return m, nil
+		}
+		m.topics = edit
+		m.topicInput.Blur()
+		include := func(r repo.Repo) bool { return r.Selected && !r.IsArchived }
+		if m.dryRun {
+			m.showPlan("topics", include)
+			return m, nil
+		}
+		return m, m.startJob("topics", include)
+	}
+
+	var cmd tea.Cmd
+	m.topicInput, cmd = m.topicInput.Update(msg)
+	m.topicErr = ""
+	return m, cmd
+}
+
+func (m Model) viewTopics() string {
+	var b strings.Builder
+
+	b.WriteString(dialogTitleStyle.Render("# Edit Topics"))
+	b.WriteString("\n\n")
+
+	targets := m.topicTargets()
+	for i, r := range targets {
+		if i == 5 {
+			b.WriteString(fmt.Sprintf("  ... and %d more\n", len(targets)-5))
+			break
+		}
+		topics := "no topics"
+		if len(r.Topics) > 0 {
+			topics = strings.Join(r.Topics, ", ")
+		}
+		b.WriteString(fmt.Sprintf("  • %s  %s\n", r.FullName, mutedStyle.Render(topics)))
+	}
+	if skipped := m.selectedCount - len(targets); skipped > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%d archived %s skipped.", skipped, pluralize(skipped, "repository is", "repositories are"))) + "\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(helpKeyStyle.Render("Topics") + " " + m.topicInput.View() + "\n")
+	if suggestions := m.topicSuggestions(); len(suggestions) > 0 {
+		if len(suggestions) > 8 {
+			suggestions = suggestions[:8]
+		}
+		b.WriteString(mutedStyle.Render("Suggestions: "+strings.Join(suggestions, ", ")) + "\n")
+	}
+	if m.topicErr != "" {
+		b.WriteString(dangerStyle.Render(m.topicErr) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Words add topics; -word removes one. Other topics are kept.") + "\n\n")
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: no topics will change.\n\n"))
+		b.WriteString(helpKeyStyle.Render("enter") + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("enter") + fmt.Sprintf(" Apply to %d  ", len(targets)))
+	}
+	b.WriteString(helpKeyStyle.Render("tab") + " Complete  ")
+	b.WriteString(helpKeyStyle.Render("esc") + " Cancel")
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// transferSpec is where a transfer moves repos
+type transferSpec struct {
+	owner   string
+	name    string // new name; only for a single repo
+	teamIDs []int
+}
+
+// run returns the client call for the transfer. Without a client every
+// transfer succeeds without doing anything.
+func (t transferSpec) run(client *gh.Client) func(fullName string) error {
+	if client == nil {
+		return func(string) error { return nil }
+	}
+	return func(fullName string) error {
+		return client.TransferRepo(fullName, t.owner, t.name, t.teamIDs)
+	}
+}
+
+// details records the destination in the audit log
+func (t transferSpec) details(r repo.Repo) map[string]string {
+	d := map[string]string{"new_owner": t.owner}
+	if t.name != "" {
+		d["new_name"] = t.name
+	}
+	if len(t.teamIDs) > 0 {
+		ids := make([]string, len(t.teamIDs))
+		for i, id := range t.teamIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		d["team_ids"] = strings.Join(ids, ",")
+	}
+	return d
+}
+
+// Fields of the transfer dialog
+const (
+	transferOwnerField = iota
+	transferNameField
+	transferTeamsField
+	numTransferFields
+)
+
+// newTransferInputs creates the text inputs of the transfer dialog
+func newTransferInputs() []textinput.Model {
+	placeholders := []string{"Owner or organization", "Keep the current name", "Team IDs, e.g. 123, 456"}
+	inputs := make([]textinput.Model, numTransferFields)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 100
+		ti.Width = 30
+		inputs[i] = ti
+	}
+	return inputs
+}
+
+// transferTargets returns the selected repos a transfer moves
+func (m Model) transferTargets() []repo.Repo {
+	var targets []repo.Repo
+	for _, r := range m.repos {
+		if r.Selected && m.protectedReason(r) == "" {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+// transferOwners suggests the owners known to the session other than the
+// one being viewed
+func (m Model) transferOwners() []string {
+	var owners []string
+	for _, o := range append([]string{m.username}, m.owners...) {
+		if o == "" || strings.EqualFold(o, m.currentOwner()) {
+			continue
+		}
+		dup := false
+		for _, seen := range owners {
+			dup = dup || strings.EqualFold(seen, o)
+		}
+		if !dup {
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
+
+// openTransfer shows the transfer dialog for the selection, or the repo
+// under the cursor
+func (m *Model) openTransfer() tea.Cmd {
+	if m.selectedCount == 0 {
+		idx := m.getActualIndex(m.cursor)
+		if idx < 0 || m.refuseProtected(m.repos[idx]) {
+			return nil
+		}
+		m.repos[idx].Selected = true
+		m.updateSelectedCount()
+	}
+	for i := range m.transferInputs {
+		m.transferInputs[i].SetValue("")
+		m.transferInputs[i].Blur()
+	}
+	if owners := m.transferOwners(); len(owners) > 0 {
+		m.transferInputs[transferOwnerField].SetValue(owners[0])
+	}
+	m.transferFocus = transferOwnerField
+	m.transferErr = ""
+	m.view = ViewTransfer
+	m.transferInputs[transferOwnerField].Focus()
+	return textinput.Blink
+}
+
+// transferFields lists the fields shown; renaming only applies to a single repo
+func (m Model) transferFields() []int {
+	if len(m.transferTargets()) == 1 {
+		return []int{transferOwnerField, transferNameField, transferTeamsField}
+	}
+	return []int{transferOwnerField, transferTeamsField}
+}
+
+// moveTransferFocus moves focus by delta through the shown fields
+func (m *Model) moveTransferFocus(delta int) tea.Cmd {
+	fields := m.transferFields()
+	pos := 0
+	for i, f := range fields {
+		if f == m.transferFocus {
+			pos = i
+		}
+	}
+	pos = (pos + delta + len(fields)) % len(fields)
+	m.transferInputs[m.transferFocus].Blur()
+	m.transferFocus = fields[pos]
+	m.transferInputs[m.transferFocus].Focus()
+	return textinput.Blink
+}
+
+// cycleTransferOwner replaces the owner with the next or previous suggestion
+func (m *Model) cycleTransferOwner(delta int) {
+	owners := m.transferOwners()
+	if len(owners) == 0 {
+		return
+	}
+	current := m.transferInputs[transferOwnerField].Value()
+	next := 0
+	for i, o := range owners {
+		if strings.EqualFold(o, current) {
+			next = (i + delta + len(owners)) % len(owners)
+		}
+	}
+	m.transferInputs[transferOwnerField].SetValue(owners[next])
+	m.transferInputs[transferOwnerField].CursorEnd()
+}
+
+// parseTransfer validates the dialog
+func (m Model) parseTransfer() (transferSpec, error) {
+	spec := transferSpec{owner: strings.TrimSpace(m.transferInputs[transferOwnerField].Value())}
+	if spec.owner == "" {
+		return spec, fmt.Errorf("enter the owner to transfer to")
+	}
+	for _, r := range m.transferTargets() {
+		if strings.EqualFold(spec.owner, r.OwnerLogin()) {
+			return spec, fmt.Errorf("%s already belongs to %s", r.FullName, spec.owner)
+		}
+	}
+	if len(m.transferTargets()) == 1 {
+		spec.name = strings.TrimSpace(m.transferInputs[transferNameField].Value())
+	}
+	for _, field := range strings.FieldsFunc(m.transferInputs[transferTeamsField].Value(), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		id, err := strconv.Atoi(field)
+		if err != nil || id <= 0 {
+			return spec, fmt.Errorf("team ID %q is not a number", field)
+		}
+		spec.teamIDs = append(spec.teamIDs, id)
+	}
+	return spec, nil
+}
+
+// handleTransferKeys handles the transfer dialog
+func (m Model) handleTransferKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		for i := range m.repos {
+			m.repos[i].Selected = false
+		}
+		m.selectedCount = 0
+		m.transferInputs[m.transferFocus].Blur()
+		m.view = ViewList
+		return m, nil
+
+	case "tab":
+		return m, m.moveTransferFocus(1)
+	case "shift+tab":
+		return m, m.moveTransferFocus(-1)
+
+	case "up", "down":
+		if m.transferFocus == transferOwnerField {
+			delta := 1
+			if msg.String() == "up" {
+				delta = -1
+			}
+			m.cycleTransferOwner(delta)
+		}
+		return m, nil
+
+	case "enter":
+		spec, err := m.parseTransfer()
+		if err != nil {
+			m.transferErr = err.Error()
+			return m, nil
+		}
+		if len(m.transferTargets()) == 0 {
+			m.transferErr = "Every selected repository is protected"
+			return m, nil
+		}
+		m.transfer = spec
+		m.transferInputs[m.transferFocus].Blur()
+		m.deselectProtected()
+		if m.dryRun {
+			m.showPlan("transfer", func(r repo.Repo) bool { return r.Selected })
+			return m, nil
+		}
+		return m, m.startJob("transfer", func(r repo.Repo) bool { return r.Selected })
+	}
+
+	var cmd tea.Cmd
+	m.transferInputs[m.transferFocus], cmd = m.transferInputs[m.transferFocus].Update(msg)
+	m.transferErr = ""
+	return m, cmd
+}
+
+func (m Model) viewTransfer() string {
+	var b strings.Builder
+
+	b.WriteString(dialogTitleStyle.Render("⇄ Transfer Repositories"))
+	b.WriteString("\n\n")
+
+	targets := m.transferTargets()
+	for i, r := range targets {
+		if i == 5 {
+			b.WriteString(fmt.Sprintf("  ... and %d more\n", len(targets)-5))
+			break
+		}
+		b.WriteString(fmt.Sprintf("  • %s\n", r.FullName))
+	}
+	if protected := m.selectedProtected(); protected > 0 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("%d protected %s skipped.", protected, pluralize(protected, "repository is", "repositories are"))) + "\n")
+	}
+	b.WriteString("\n")
+
+	labels := map[int]string{
+		transferOwnerField: "To owner",
+		transferNameField:  "New name",
+		transferTeamsField: "Teams",
+	}
+	for _, f := range m.transferFields() {
+		label := fmt.Sprintf("%-9s", labels[f])
+		if f == m.transferFocus {
+			label = helpKeyStyle.Render(label)
+		} else {
+			label = mutedStyle.Render(label)
+		}
+		b.WriteString(label + " " + m.transferInputs[f].View() + "\n")
+	}
+	if owners := m.transferOwners(); len(owners) > 0 {
+		b.WriteString(mutedStyle.Render("Known owners: "+strings.Join(owners, ", ")) + "\n")
+	}
+	if m.transferErr != "" {
+		b.WriteString(dangerStyle.Render(m.transferErr) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Users must accept transfers to them. Team IDs only apply to organizations.") + "\n\n")
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will be transferred.\n\n"))
+		b.WriteString(helpKeyStyle.Render("enter") + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("enter") + fmt.Sprintf(" Transfer %d  ", len(targets)))
+	}
+	b.WriteString(helpKeyStyle.Render("esc") + " Cancel\n")
+	b.WriteString(helpKeyStyle.Render("tab") + " Next field  " + helpKeyStyle.Render("↑/↓") + " Pick owner")
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// recordUndo remembers the repos a finished archive job archived, so the
+// batch can be unarchived again for the rest of the session
+func (m *Model) recordUndo(job *bulkJob) {
+	if job.action != "archive" {
+		return
+	}
+	var batch []repo.Repo
+	for _, item := range job.items {
+		if item.status == jobSucceeded {
+			batch = append(batch, item.repo)
+		}
+	}
+	if len(batch) > 0 {
+		m.undoStack = append(m.undoStack, batch)
+	}
+}
+
+// undoArchive unarchives the most recent archive batch
+func (m *Model) undoArchive() tea.Cmd {
+	if len(m.undoStack) == 0 {
+		m.message = "Nothing to undo"
+		m.messageIsError = false
+		return nil
+	}
+	if m.job != nil && !m.job.done() {
+		m.message = "Wait for the running operation to finish before undoing"
+		m.messageIsError = true
+		return nil
+	}
+	batch := m.undoStack[len(m.undoStack)-1]
+	if m.dryRun {
+		m.message = fmt.Sprintf("Dry run on: undo would unarchive %d %s", len(batch), pluralize(len(batch), "repository", "repositories"))
+		m.messageIsError = false
+		return nil
+	}
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	return m.runJob("unarchive", batch)
+}
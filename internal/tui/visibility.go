@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/gh-repo-review/internal/repo"
+)
+
+// visibilities are the targets the visibility dialog cycles through
+var visibilities = []string{"private", "public", "internal"}
+
+// visibilityOf returns the visibility a make-* action sets
+func visibilityOf(action string) (string, bool) {
+	v := strings.TrimPrefix(action, "make-")
+	for _, known := range visibilities {
+		if v == known && action != v {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// changesVisibility reports whether making r target would change it.
+// Archived repos are read-only and can't change visibility.
+func (m Model) changesVisibility(r repo.Repo, target string) bool {
+	return r.Selected && !r.IsArchived && m.protectedReason(r) == "" &&
+		strings.ToLower(r.VisibilityString()) != target
+}
+
+// detachesForks reports whether making r private would detach forks or drop stars
+func detachesForks(r repo.Repo, target string) bool {
+	return target == "private" && !r.IsPrivate && (r.ForkCount > 0 || r.StargazerCount > 0)
+}
+
+// openConfirmVisibility shows the visibility dialog for the selection, or
+// the repo under the cursor
+func (m *Model) openConfirmVisibility() {
+	if m.selectedCount == 0 {
+		idx := m.getActualIndex(m.cursor)
+		if idx < 0 || m.refuseProtected(m.repos[idx]) {
+			return
+		}
+		m.repos[idx].Selected = true
+		m.updateSelectedCount()
+	}
+	if m.visibilityTarget == "" {
+		m.visibilityTarget = "private"
+	}
+	m.view = ViewConfirmVisibility
+}
+
+// handleConfirmVisibilityKeys handles the visibility dialog
+func (m Model) handleConfirmVisibilityKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "v":
+		for i, v := range visibilities {
+			if v == m.visibilityTarget {
+				m.visibilityTarget = visibilities[(i+1)%len(visibilities)]
+				break
+			}
+		}
+
+	case "y", "Y":
+		target := m.visibilityTarget
+		include := func(r repo.Repo) bool { return m.changesVisibility(r, target) }
+		if m.dryRun {
+			m.showPlan("make-"+target, include)
+			return m, nil
+		}
+		return m, m.startJob("make-"+target, include)
+
+	case "n", "N", "esc", "q":
+		for i := range m.repos {
+			m.repos[i].Selected = false
+		}
+		m.selectedCount = 0
+		m.view = ViewList
+	}
+	return m, nil
+}
+
+func (m Model) viewConfirmVisibility() string {
+	var b strings.Builder
+	target := m.visibilityTarget
+
+	b.WriteString(dialogTitleStyle.Render("◐ Change Visibility"))
+	b.WriteString("\n\n")
+
+	// List repos that would change, and those making it private would hurt
+	count, skipped := 0, 0
+	var detached []repo.Repo
+	for _, r := range m.repos {
+		if !r.Selected {
+			continue
+		}
+		if !m.changesVisibility(r, target) {
+			skipped++
+			continue
+		}
+		count++
+		if count <= 5 {
+			b.WriteString(fmt.Sprintf("  • %s  %s\n", r.FullName, mutedStyle.Render(strings.ToLower(r.VisibilityString())+" → "+target)))
+		}
+		if detachesForks(r, target) {
+			detached = append(detached, r)
+		}
+	}
+	if count > 5 {
+		b.WriteString(fmt.Sprintf("  ... and %d more\n", count-5))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Make %d %s %s?\n", count, pluralize(count, "repository", "repositories"), target))
+	if skipped > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%d already %s, archived or protected, skipped.", skipped, target)) + "\n")
+	}
+	if len(detached) > 0 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ %d public %s forks or stars. Making them private detaches their forks and removes their stars:",
+			len(detached), pluralize(len(detached), "repository has", "repositories have"))) + "\n")
+		for i, r := range detached {
+			if i == 3 {
+				b.WriteString(warningStyle.Render(fmt.Sprintf("    ... and %d more", len(detached)-3)) + "\n")
+				break
+			}
+			b.WriteString(warningStyle.Render(fmt.Sprintf("    %s (★ %d, %d forks)", r.FullName, r.StargazerCount, r.ForkCount)) + "\n")
+		}
+	}
+	if target == "internal" {
+		b.WriteString(mutedStyle.Render("Internal visibility needs an organization on GitHub Enterprise.") + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.dryRun {
+		b.WriteString(warningStyle.Render("Dry run: nothing will change.\n\n"))
+		b.WriteString(helpKeyStyle.Render("y") + " Show plan  ")
+	} else {
+		b.WriteString(helpKeyStyle.Render("y") + " Yes, change  ")
+	}
+	b.WriteString(helpKeyStyle.Render("n") + " No, cancel  ")
+	b.WriteString(helpKeyStyle.Render("tab") + " Target: " + target)
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,175 @@
+// ABOUTME: Bounded worker pool for bulk API mutations.
+// ABOUTME: Spaces out requests, pauses every worker on rate limits and retries transient failures.
+
+package worker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/gh"
+)
+
+// Defaults follow GitHub's guidance for mutating requests: few concurrent
+// requests and at least a second between them
+const (
+	DefaultWorkers     = 4
+	DefaultMinInterval = time.Second
+	DefaultMaxAttempts = 5
+)
+
+// Task is one unit of work, usually a single API mutation
+type Task func() error
+
+// State is the kind of an Event
+type State int
+
+const (
+	// Started is sent when a task begins its first attempt
+	Started State = iota
+	// Retrying is sent when an attempt failed and the task will run again after Wait
+	Retrying
+	// Finished is sent once per task with its final error
+	Finished
+)
+
+// Event reports the progress of one task, identified by its index
+type Event struct {
+	Index   int
+	State   State
+	Attempt int
+	Wait    time.Duration
+	Err     error
+}
+
+// Options configures a Pool; zero values use the defaults
+type Options struct {
+	Workers     int
+	MinInterval time.Duration
+	MaxAttempts int
+	// RetryDelay classifies errors; defaults to gh.RetryDelay
+	RetryDelay func(err error, attempt int) (time.Duration, bool)
+}
+
+// Pool runs tasks with bounded concurrency. Pacing and rate-limit pauses are
+// shared by every Run on the same Pool.
+type Pool struct {
+	opts Options
+
+	mu          sync.Mutex
+	next        time.Time
+	pausedUntil time.Time
+}
+
+// New creates a pool
+func New(opts Options) *Pool {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.MinInterval < 0 {
+		opts.MinInterval = 0
+	} else if opts.MinInterval == 0 {
+		opts.MinInterval = DefaultMinInterval
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.RetryDelay == nil {
+		opts.RetryDelay = gh.RetryDelay
+	}
+	return &Pool{opts: opts}
+}
+
+// Workers returns the pool size
+func (p *Pool) Workers() int {
+	return p.opts.Workers
+}
+
+// Run starts the tasks and returns a channel of events that is closed once
+// every task has finished
+func (p *Pool) Run(tasks []Task) <-chan Event {
+	events := make(chan Event, len(tasks))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	workers := p.opts.Workers
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				p.runTask(i, tasks[i], events)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range tasks {
+			queue <- i
+		}
+		close(queue)
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// runTask runs one task until it succeeds, fails permanently or runs out of attempts
+func (p *Pool) runTask(index int, task Task, events chan<- Event) {
+	for attempt := 1; ; attempt++ {
+		p.wait()
+		if attempt == 1 {
+			events <- Event{Index: index, State: Started, Attempt: attempt}
+		}
+
+		err := task()
+		if err == nil {
+			events <- Event{Index: index, State: Finished, Attempt: attempt}
+			return
+		}
+
+		delay, retry := p.opts.RetryDelay(err, attempt)
+		if !retry || attempt >= p.opts.MaxAttempts {
+			events <- Event{Index: index, State: Finished, Attempt: attempt, Err: err}
+			return
+		}
+
+		// Every worker waits out a rate limit, not just the one that hit it
+		var apiErr *gh.APIError
+		if errors.As(err, &apiErr) && apiErr.IsRateLimit() {
+			p.pause(delay)
+		}
+		events <- Event{Index: index, State: Retrying, Attempt: attempt, Wait: delay, Err: err}
+		time.Sleep(delay)
+	}
+}
+
+// wait blocks until the next request may start
+func (p *Pool) wait() {
+	p.mu.Lock()
+	start := time.Now()
+	if p.next.After(start) {
+		start = p.next
+	}
+	if p.pausedUntil.After(start) {
+		start = p.pausedUntil
+	}
+	p.next = start.Add(p.opts.MinInterval)
+	p.mu.Unlock()
+
+	time.Sleep(time.Until(start))
+}
+
+// pause holds back every worker for d
+func (p *Pool) pause(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.pausedUntil) {
+		p.pausedUntil = until
+	}
+}
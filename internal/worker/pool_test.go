@@ -0,0 +1,151 @@
+// ABOUTME: Tests for the worker pool's retry and rate-limit pause behaviour.
+// ABOUTME: Uses gh.APIError values so retries follow the client's classification.
+
+package worker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/user/gh-repo-review/internal/gh"
+)
+
+// fastOptions keeps tests quick: no spacing and tiny retry delays
+func fastOptions() Options {
+	return Options{
+		Workers:     2,
+		MinInterval: -1,
+		MaxAttempts: 3,
+		RetryDelay: func(err error, attempt int) (time.Duration, bool) {
+			d, ok := gh.RetryDelay(err, attempt)
+			if d > 10*time.Millisecond {
+				d = 10 * time.Millisecond
+			}
+			return d, ok
+		},
+	}
+}
+
+// failing returns a task that fails with errs in turn, then succeeds
+func failing(calls *int, errs ...error) Task {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestRunTaskRetries(t *testing.T) {
+	serverErr := func(repeatable bool) error {
+		return &gh.APIError{StatusCode: 502, Message: "bad gateway", RateLimitRemaining: -1, Repeatable: repeatable}
+	}
+	rateLimit := &gh.APIError{StatusCode: 429, Message: "rate limit", RateLimitRemaining: 0, RetryAfter: time.Millisecond}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"success", nil, 1, false},
+		{"repeatable server error retried", []error{serverErr(true)}, 2, false},
+		{"unsafe server error not retried", []error{serverErr(false)}, 1, true},
+		{"timeout of an unsafe request not retried", []error{&gh.APIError{Message: "timeout", RateLimitRemaining: -1}}, 1, true},
+		{"rate limit retried even when unsafe", []error{rateLimit}, 2, false},
+		{"client error not retried", []error{&gh.APIError{StatusCode: 422, Message: "invalid", Repeatable: true}}, 1, true},
+		{"other errors not retried", []error{errors.New("gh not found")}, 1, true},
+		{"gives up after max attempts", []error{serverErr(true), serverErr(true), serverErr(true)}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var finished []Event
+			retries := 0
+			for ev := range New(fastOptions()).Run([]Task{failing(&calls, tt.errs...)}) {
+				switch ev.State {
+				case Retrying:
+					retries++
+				case Finished:
+					finished = append(finished, ev)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("task ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if retries != tt.wantCalls-1 {
+				t.Errorf("got %d Retrying events, want %d", retries, tt.wantCalls-1)
+			}
+			if len(finished) != 1 {
+				t.Fatalf("got %d Finished events, want 1", len(finished))
+			}
+			if (finished[0].Err != nil) != tt.wantErr {
+				t.Errorf("finished with %v, want error: %v", finished[0].Err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateLimitPausesEveryWorker(t *testing.T) {
+	const pause = 100 * time.Millisecond
+	opts := fastOptions()
+	opts.RetryDelay = gh.RetryDelay
+
+	var mu sync.Mutex
+	var limitedAt time.Time
+	var starts []time.Time
+	limited := make(chan struct{})
+	first := true
+	tasks := []Task{
+		// Hits a rate limit on its first attempt
+		func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			if first {
+				first = false
+				limitedAt = time.Now()
+				defer close(limited)
+				return &gh.APIError{StatusCode: 403, Message: "secondary rate limit", RetryAfter: pause, RateLimitRemaining: -1}
+			}
+			return nil
+		},
+		// Keeps the second worker busy until the rate limit was hit
+		func() error {
+			<-limited
+			return nil
+		},
+	}
+	for i := 0; i < 3; i++ {
+		tasks = append(tasks, func() error {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	for range New(opts).Run(tasks) {
+	}
+	if len(starts) != 3 {
+		t.Fatalf("%d of 3 tasks ran", len(starts))
+	}
+	for _, s := range starts {
+		// Allow for the pause starting just after the error is returned
+		if waited := s.Sub(limitedAt); waited < pause-10*time.Millisecond {
+			t.Errorf("a task started %s after the rate limit, before the %s pause ended", waited, pause)
+		}
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	p := New(Options{})
+	if p.Workers() != DefaultWorkers {
+		t.Errorf("got %d workers, want %d", p.Workers(), DefaultWorkers)
+	}
+	if p.opts.MinInterval != DefaultMinInterval || p.opts.MaxAttempts != DefaultMaxAttempts {
+		t.Errorf("got interval %s and %d attempts, want the defaults", p.opts.MinInterval, p.opts.MaxAttempts)
+	}
+}
@@ -1,18 +1,115 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/user/gh-repo-review/internal/backup"
+	"github.com/user/gh-repo-review/internal/cli"
+	"github.com/user/gh-repo-review/internal/config"
+	"github.com/user/gh-repo-review/internal/gh"
+	"github.com/user/gh-repo-review/internal/policy"
 	"github.com/user/gh-repo-review/internal/tui"
+	"github.com/user/gh-repo-review/internal/worker"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	p := tea.NewProgram(tui.NewModel(), tea.WithAltScreen())
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if err := cli.Run(args, os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts, err := parseTUIFlags(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	p := tea.NewProgram(tui.NewModel(opts), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// parseTUIFlags parses the flags accepted when starting the TUI
+func parseTUIFlags(args []string) (tui.Options, error) {
+	var opts tui.Options
+	var owners cli.StringList
+	var affiliation, policyPath, preset string
+
+	fs := flag.NewFlagSet("gh-repo-review", flag.ContinueOnError)
+	fs.Var(&owners, "owner", "User or organization to review (repeatable, default: you and your organizations)")
+	fs.StringVar(&affiliation, "affiliation", "owner", "Your affiliations to include: owner, collaborator, organization_member")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "Start in dry-run mode: archive and delete only produce a plan")
+	fs.BoolVar(&opts.Backup, "backup", false, "Back up repositories before deleting them")
+	fs.StringVar(&opts.BackupDir, "backup-dir", "", "Backup directory (default ~/.local/share/gh-repo-review/backups)")
+	fs.IntVar(&opts.Concurrency, "concurrency", worker.DefaultWorkers, "Number of repositories to change in parallel in bulk actions")
+	fs.StringVar(&policyPath, "policy", "", "Retention policy to highlight violations of (default ~/.config/gh-repo-review/policy.yml if present)")
+	fs.StringVar(&preset, "preset", "", "Start with a saved filter preset from ~/.config/gh-repo-review/config.yml")
+	fs.Usage = func() {
+		cli.Usage(fs.Output())
+		fmt.Fprintln(fs.Output(), "\nTUI flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.Concurrency < 1 {
+		return opts, fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	affiliations, err := gh.ParseAffiliations(affiliation)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.BackupDir == "" {
+		dir, err := backup.DefaultDir()
+		if err != nil {
+			return opts, err
+		}
+		opts.BackupDir = dir
+	}
+
+	if policyPath != "" {
+		opts.Policy, err = policy.Load(policyPath)
+	} else {
+		opts.Policy, err = policy.LoadDefault()
+	}
+	if err != nil {
+		return opts, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	opts.Config, err = config.Load()
+	if err != nil {
+		return opts, fmt.Errorf("invalid config: %w", err)
+	}
+	if preset != "" {
+		filters, err := opts.Config.PresetOptions(preset)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = &filters
+		if p, ok := opts.Config.Preset(preset); ok {
+			opts.Preset = p.Name
+		}
+	}
+
+	opts.Owners = owners
+	opts.Affiliations = affiliations
+	return opts, nil
+}